@@ -1,12 +1,26 @@
 package epub
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/coghost/toolbox/pathlib"
 	"github.com/go-shiori/go-epub"
 	"github.com/ungerik/go-dry"
 )
 
+var (
+	ErrEmptySection = errors.New("section file is empty: the first line must be the chapter name")
+	ErrNoChapters   = errors.New("no chapter files found")
+)
+
+// defaultChapterPattern is the glob NewEBookFromDir uses when no pattern is given.
+const defaultChapterPattern = "*.html"
+
 type EBook struct {
 	Name   string
 	Author string
@@ -47,6 +61,62 @@ func NewEBook(bookname string, author string) (*EBook, error) {
 	}, nil
 }
 
+// NewEBookFromDir creates a book from all the chapter files in dir matching the glob pattern
+// ("*.html" when empty), added in natural order so "2.html" comes before "10.html".
+//
+// Each file follows the AddFiles format: the first line is the chapter name and the other
+// lines are the paragraphs. Directories matching the pattern are ignored.
+//
+// It returns an error wrapping ErrNoChapters if no file matches, and ErrEmptySection if a
+// chapter file is empty.
+//
+// Example:
+//
+//	book, err := NewEBookFromDir("My Novel", "Jane Doe", "/data/novel/chapters", "*.txt")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = book.Save("my-novel.epub")
+func NewEBookFromDir(bookname, author, dir string, pattern string) (*EBook, error) {
+	if pattern == "" {
+		pattern = defaultChapterPattern
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*pathlib.FsPath{}
+
+	for _, match := range matches {
+		if p := pathlib.Path(match); p.IsFile() {
+			files = append(files, p)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoChapters, filepath.Join(dir, pattern))
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return NaturalLess(files[i].Name, files[j].Name)
+	})
+
+	e, err := NewEBook(bookname, author)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if err := e.AddSectionFromPath(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
 // AddFiles
 //
 //	file format:
@@ -67,21 +137,210 @@ func (c *EBook) AddFiles(files []string) error {
 	return nil
 }
 
-func (c *EBook) AddSectionByFile(header string, paragraphs []string) error {
-	body := fmt.Sprintf("<h1>%s</h1>", header)
+// AddFilesSorted adds files like AddFiles, but first sorts them in natural order,
+// so "2.html" comes before "10.html". The files slice itself is not modified.
+//
+// An optional less function replaces the natural ordering.
+//
+// Example:
+//
+//	err := book.AddFilesSorted([]string{"ch/10.html", "ch/2.html", "ch/1.html"})
+//	// chapters are added as 1, 2, 10
+func (c *EBook) AddFilesSorted(files []string, less ...func(a, b string) bool) error {
+	cmp := NaturalLess
+	if len(less) > 0 && less[0] != nil {
+		cmp = less[0]
+	}
 
-	for _, l := range paragraphs {
-		body += fmt.Sprintf("<p>%s</p>", l)
+	sorted := append([]string(nil), files...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return cmp(sorted[i], sorted[j])
+	})
+
+	return c.AddFiles(sorted)
+}
+
+// NaturalLess reports whether a sorts before b in natural order: runs of digits
+// are compared by numeric value and everything else byte by byte,
+// e.g. "file2" < "file10" and "v1.9" < "v1.10".
+func NaturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+
+		var aChunk, bChunk string
+
+		aChunk, a = nextChunk(a, aDigits)
+		bChunk, b = nextChunk(b, bDigits)
+
+		if aDigits && bDigits {
+			aNum, bNum := strings.TrimLeft(aChunk, "0"), strings.TrimLeft(bChunk, "0")
+			if len(aNum) != len(bNum) {
+				return len(aNum) < len(bNum)
+			}
+
+			if aNum != bNum {
+				return aNum < bNum
+			}
+
+			// equal values: fewer leading zeros first, so "1" < "01"
+			if len(aChunk) != len(bChunk) {
+				return len(aChunk) < len(bChunk)
+			}
+
+			continue
+		}
+
+		if aChunk != bChunk {
+			return aChunk < bChunk
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// nextChunk splits off the leading run of digits (or non-digits) of s.
+func nextChunk(s string, digits bool) (chunk, rest string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
 	}
 
-	_, err := c.Epub.AddSection(body, header, "", "")
+	return s[:i], s[i:]
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// AddLargeFile adds a single large file as several sections of at most maxParagraphs
+// paragraphs each, so e-readers don't have to render one giant chapter.
+//
+// The file format is the same as AddFiles: the first line is the title and every other
+// line is a paragraph, so sections never split a paragraph. When the body needs more than
+// one section, they are titled "Title (1/3)", "Title (2/3)", ...; otherwise the plain title is used.
+// A maxParagraphs of 0 or less disables splitting.
+//
+// Example:
+//
+//	err := book.AddLargeFile("novels/war-and-peace.txt", 200)
+func (c *EBook) AddLargeFile(path string, maxParagraphs int) error {
+	lines, err := dry.FileGetLines(path)
 	if err != nil {
-		return fmt.Errorf("cannot add section: %w", err)
+		return err
+	}
+
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptySection, path)
+	}
+
+	title, paragraphs := lines[0], lines[1:]
+
+	if maxParagraphs <= 0 || len(paragraphs) <= maxParagraphs {
+		return c.AddSectionByFile(title, paragraphs)
+	}
+
+	parts := (len(paragraphs) + maxParagraphs - 1) / maxParagraphs
+
+	for i := 0; i < parts; i++ {
+		end := min((i+1)*maxParagraphs, len(paragraphs))
+		partTitle := fmt.Sprintf("%s (%d/%d)", title, i+1, parts)
+
+		if err := c.AddSectionByFile(partTitle, paragraphs[i*maxParagraphs:end]); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// AddSectionFromPath adds a section from a pathlib.FsPath, following the same
+// file format as AddFiles: the first line is the chapter name and the
+// remaining lines are the body paragraphs.
+//
+// The file is read with pathlib's GetLines, so it works on any file system
+// backing the FsPath and strips Windows line endings.
+func (c *EBook) AddSectionFromPath(p *pathlib.FsPath) error {
+	lines, err := p.GetLines()
+	if err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptySection, p)
+	}
+
+	return c.AddSectionByFile(lines[0], lines[1:])
+}
+
+func (c *EBook) AddSectionByFile(header string, paragraphs []string) error {
+	_, err := c.AddSection(header, sectionBody(header, paragraphs))
+
+	return err
+}
+
+// AddSection adds a top-level section (chapter) with the given title and XHTML body.
+//
+// It returns the internal filename of the section, which can be passed to
+// AddSubSection to nest sections under it in the table of contents.
+func (c *EBook) AddSection(title, htmlBody string) (string, error) {
+	filename, err := c.Epub.AddSection(htmlBody, title, "", "")
+	if err != nil {
+		return "", fmt.Errorf("cannot add section: %w", err)
+	}
+
+	return filename, nil
+}
+
+// AddSubSection adds a section nested under the section identified by parentFilename,
+// as returned by AddSection or AddSubSection. It returns the internal filename of the new section.
+//
+// Example:
+//
+//	part, _ := book.AddSection("Part One", "<h1>Part One</h1>")
+//	chapter, _ := book.AddSubSection(part, "Chapter 1", "<h1>Chapter 1</h1><p>...</p>")
+func (c *EBook) AddSubSection(parentFilename, title, htmlBody string) (string, error) {
+	filename, err := c.Epub.AddSubSection(parentFilename, htmlBody, title, "", "")
+	if err != nil {
+		return "", fmt.Errorf("cannot add subsection: %w", err)
+	}
+
+	return filename, nil
+}
+
+// sectionBody renders a header and its paragraphs as the XHTML body of a section.
+func sectionBody(header string, paragraphs []string) string {
+	body := fmt.Sprintf("<h1>%s</h1>", header)
+
+	for _, l := range paragraphs {
+		body += fmt.Sprintf("<p>%s</p>", l)
+	}
+
+	return body
+}
+
 func (c *EBook) Save(filename string) error {
 	return c.Epub.Write(filename)
 }
+
+// Bytes renders the epub in memory and returns the archive content,
+// without writing anything to disk.
+func (c *EBook) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if _, err := c.Epub.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SaveTo renders the epub in memory and writes it to p with pathlib's SetBytes,
+// so it works on whatever file system backs p and creates missing parent directories.
+func (c *EBook) SaveTo(p *pathlib.FsPath) error {
+	data, err := c.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return p.SetBytes(data)
+}
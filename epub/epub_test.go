@@ -1,11 +1,16 @@
 package epub
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/coghost/toolbox/pathlib"
+	"github.com/spf13/afero"
 	"github.com/spf13/cast"
 	"github.com/stretchr/testify/suite"
 	"github.com/ungerik/go-dry"
@@ -44,3 +49,180 @@ func (s *EBookSuite) TestNewEBook() {
 
 	NewEBookWithFiles("xx", "zxy", files)
 }
+
+// epubFiles renders the book in memory and returns the content of each file in the archive.
+func (s *EBookSuite) epubFiles(book *EBook) map[string]string {
+	var buf bytes.Buffer
+
+	_, err := book.Epub.WriteTo(&buf)
+	s.Require().NoError(err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.Require().NoError(err)
+
+	files := map[string]string{}
+
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		s.Require().NoError(err)
+
+		data, err := io.ReadAll(rc)
+		s.Require().NoError(err)
+		rc.Close()
+
+		files[f.Name] = string(data)
+	}
+
+	return files
+}
+
+func (s *EBookSuite) TestAddSectionFromPath() {
+	chapter := pathlib.Path(s.T().TempDir()).Join("1.txt")
+	s.Require().NoError(chapter.WriteText("Chapter One\r\nfirst paragraph\r\nsecond paragraph\r\n"))
+
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(book.AddSectionFromPath(chapter))
+
+	section := s.epubFiles(book)["EPUB/xhtml/section0001.xhtml"]
+	s.Contains(section, "<h1>Chapter One</h1>")
+	s.Contains(section, "<p>first paragraph</p><p>second paragraph</p>")
+
+	empty := pathlib.Path(s.T().TempDir()).Join("empty.txt")
+	s.Require().NoError(empty.WriteText(""))
+	s.ErrorIs(book.AddSectionFromPath(empty), ErrEmptySection)
+}
+
+func (s *EBookSuite) TestBytesAndSaveTo() {
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(book.AddSectionByFile("Chapter One", []string{"paragraph"}))
+
+	data, err := book.Bytes()
+	s.Require().NoError(err)
+	s.NotEmpty(data)
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	s.Require().NoError(err)
+	s.Equal("mimetype", reader.File[0].Name)
+
+	memFs := afero.NewMemMapFs()
+	target := pathlib.Path(s.T().TempDir()).Join("out", "book.epub").WithFs(memFs)
+	s.Require().NoError(book.SaveTo(target))
+
+	s.NoFileExists(target.String(), "the book must not be written to the OS file system")
+
+	saved, err := afero.ReadFile(memFs, target.String())
+	s.Require().NoError(err)
+
+	reader, err = zip.NewReader(bytes.NewReader(saved), int64(len(saved)))
+	s.Require().NoError(err)
+	s.Equal("mimetype", reader.File[0].Name)
+}
+
+func (s *EBookSuite) TestAddSubSection() {
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+
+	part, err := book.AddSection("Part One", "<h1>Part One</h1>")
+	s.Require().NoError(err)
+	s.NotEmpty(part)
+
+	chapter, err := book.AddSubSection(part, "Chapter 1", "<h1>Chapter 1</h1><p>text</p>")
+	s.Require().NoError(err)
+	s.NotEqual(part, chapter)
+
+	_, err = book.AddSubSection("missing.xhtml", "Orphan", "<p>orphan</p>")
+	s.Error(err)
+
+	// the chapter is nested in an inner list under the part
+	nav := s.epubFiles(book)["EPUB/nav.xhtml"]
+	s.Regexp(`(?s)>Part One</a>\s*<ol>\s*<li>\s*<a href="xhtml/`+chapter+`">Chapter 1</a>`, nav)
+}
+
+func (s *EBookSuite) TestAddFilesSorted() {
+	dir := pathlib.Path(s.T().TempDir())
+
+	var files []string
+
+	for _, n := range []int{10, 2, 11, 1} {
+		file := dir.Join(fmt.Sprintf("%d.html", n))
+		s.Require().NoError(file.WriteText(fmt.Sprintf("Chapter %d\nbody", n)))
+		files = append(files, file.String())
+	}
+
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(book.AddFilesSorted(files))
+	s.Equal(dir.Join("10.html").String(), files[0], "the input slice is left untouched")
+
+	nav := s.epubFiles(book)["EPUB/nav.xhtml"]
+	s.Regexp(`(?s)Chapter 1<.*Chapter 2<.*Chapter 10<.*Chapter 11<`, nav)
+
+	reversed, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(reversed.AddFilesSorted(files, func(a, b string) bool { return NaturalLess(b, a) }))
+
+	nav = s.epubFiles(reversed)["EPUB/nav.xhtml"]
+	s.Regexp(`(?s)Chapter 11<.*Chapter 10<.*Chapter 2<.*Chapter 1<`, nav)
+}
+
+func (s *EBookSuite) TestNewEBookFromDir() {
+	dir := pathlib.Path(s.T().TempDir())
+
+	for _, n := range []int{10, 2, 1} {
+		s.Require().NoError(dir.Join(fmt.Sprintf("%d.html", n)).WriteText(fmt.Sprintf("Chapter %d\nbody %d", n, n)))
+	}
+
+	s.Require().NoError(dir.Join("notes.txt").WriteText("Notes\nnot a chapter"))
+	s.Require().NoError(dir.Join("sub.html").Mkdirs())
+
+	book, err := NewEBookFromDir("book", "author", dir.String(), "")
+	s.Require().NoError(err)
+	s.Equal("author", book.Author)
+
+	files := s.epubFiles(book)
+	s.Regexp(`(?s)Chapter 1<.*Chapter 2<.*Chapter 10<`, files["EPUB/nav.xhtml"])
+	s.NotContains(files["EPUB/nav.xhtml"], "Notes")
+	s.Contains(files["EPUB/xhtml/section0001.xhtml"], "<p>body 1</p>")
+
+	txt, err := NewEBookFromDir("book", "author", dir.String(), "*.txt")
+	s.Require().NoError(err)
+	s.Contains(s.epubFiles(txt)["EPUB/nav.xhtml"], "Notes")
+
+	_, err = NewEBookFromDir("book", "author", dir.String(), "*.md")
+	s.ErrorIs(err, ErrNoChapters)
+}
+
+func (s *EBookSuite) TestNaturalLess() {
+	s.True(NaturalLess("2.html", "10.html"))
+	s.False(NaturalLess("10.html", "2.html"))
+	s.True(NaturalLess("v1.9", "v1.10"))
+	s.True(NaturalLess("ch1", "ch01"))
+	s.True(NaturalLess("a", "ab"))
+	s.False(NaturalLess("same", "same"))
+}
+
+func (s *EBookSuite) TestAddLargeFile() {
+	lines := []string{"Novel"}
+	for i := 1; i <= 7; i++ {
+		lines = append(lines, fmt.Sprintf("paragraph %d", i))
+	}
+
+	file := pathlib.Path(s.T().TempDir()).Join("novel.txt")
+	s.Require().NoError(file.WriteText(strings.Join(lines, "\n")))
+
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(book.AddLargeFile(file.String(), 3))
+
+	files := s.epubFiles(book)
+	s.Contains(files["EPUB/xhtml/section0001.xhtml"], "<h1>Novel (1/3)</h1><p>paragraph 1</p><p>paragraph 2</p><p>paragraph 3</p>")
+	s.Contains(files["EPUB/xhtml/section0003.xhtml"], "<h1>Novel (3/3)</h1><p>paragraph 7</p>")
+	s.NotContains(files, "EPUB/xhtml/section0004.xhtml")
+
+	small, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(small.AddLargeFile(file.String(), 10))
+	s.Contains(s.epubFiles(small)["EPUB/xhtml/section0001.xhtml"], "<h1>Novel</h1>")
+}
@@ -0,0 +1,197 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidEpub = errors.New("invalid epub")
+
+const epubMimetype = "application/epub+zip"
+
+// resourceRefRegex matches the src and href attributes of XHTML documents.
+var resourceRefRegex = regexp.MustCompile(`(?:src|href)="([^"]*)"`)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Items []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Itemrefs []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// Validate renders the book in memory and checks that the result is a well-formed epub:
+//   - the book has a title and at least one section;
+//   - the archive starts with the "mimetype" entry and has a container pointing to the package document;
+//   - every file listed in the package manifest is present, and every spine entry is in the manifest;
+//   - every relative image, CSS or link reference in the XHTML documents points to an embedded file.
+//
+// It returns an error wrapping ErrInvalidEpub describing the first problem found,
+// or the rendering error if the book cannot be written at all.
+//
+// Example:
+//
+//	if err := book.Validate(); err != nil {
+//	    log.Fatalf("refusing to ship broken epub: %v", err)
+//	}
+//	err := book.Save("book.epub")
+func (c *EBook) Validate() error {
+	if strings.TrimSpace(c.Epub.Title()) == "" {
+		return fmt.Errorf("%w: missing title", ErrInvalidEpub)
+	}
+
+	data, err := c.Bytes()
+	if err != nil {
+		return err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("%w: not a zip archive: %w", ErrInvalidEpub, err)
+	}
+
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	if len(reader.File) == 0 || reader.File[0].Name != "mimetype" {
+		return fmt.Errorf("%w: the first entry must be mimetype", ErrInvalidEpub)
+	}
+
+	if mimetype, err := readZipFile(reader.File[0]); err != nil || string(mimetype) != epubMimetype {
+		return fmt.Errorf("%w: mimetype must be %s", ErrInvalidEpub, epubMimetype)
+	}
+
+	opfPath, err := packageDocumentPath(files)
+	if err != nil {
+		return err
+	}
+
+	return validatePackage(files, opfPath)
+}
+
+// packageDocumentPath reads META-INF/container.xml and returns the path of the package document.
+func packageDocumentPath(files map[string]*zip.File) (string, error) {
+	containerFile, ok := files["META-INF/container.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: missing META-INF/container.xml", ErrInvalidEpub)
+	}
+
+	var container epubContainer
+	if err := unmarshalZipFile(containerFile, &container); err != nil {
+		return "", err
+	}
+
+	if len(container.Rootfiles) == 0 {
+		return "", fmt.Errorf("%w: container.xml has no rootfile", ErrInvalidEpub)
+	}
+
+	opfPath := container.Rootfiles[0].FullPath
+	if _, ok := files[opfPath]; !ok {
+		return "", fmt.Errorf("%w: missing package document %s", ErrInvalidEpub, opfPath)
+	}
+
+	return opfPath, nil
+}
+
+// validatePackage checks the manifest, the spine and the resources referenced by XHTML documents.
+func validatePackage(files map[string]*zip.File, opfPath string) error {
+	var pkg epubPackage
+	if err := unmarshalZipFile(files[opfPath], &pkg); err != nil {
+		return err
+	}
+
+	baseDir := path.Dir(opfPath)
+	manifest := make(map[string]bool, len(pkg.Items))
+
+	for _, item := range pkg.Items {
+		manifest[item.ID] = true
+
+		itemPath := path.Join(baseDir, item.Href)
+		if _, ok := files[itemPath]; !ok {
+			return fmt.Errorf("%w: manifest item %s is missing from the archive", ErrInvalidEpub, itemPath)
+		}
+
+		if item.MediaType == "application/xhtml+xml" {
+			if err := validateReferences(files, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(pkg.Itemrefs) == 0 {
+		return fmt.Errorf("%w: the book has no sections", ErrInvalidEpub)
+	}
+
+	for _, ref := range pkg.Itemrefs {
+		if !manifest[ref.IDRef] {
+			return fmt.Errorf("%w: spine entry %s is not in the manifest", ErrInvalidEpub, ref.IDRef)
+		}
+	}
+
+	return nil
+}
+
+// validateReferences checks that relative src/href references of an XHTML document exist in the archive.
+func validateReferences(files map[string]*zip.File, docPath string) error {
+	content, err := readZipFile(files[docPath])
+	if err != nil {
+		return err
+	}
+
+	for _, match := range resourceRefRegex.FindAllSubmatch(content, -1) {
+		ref := string(match[1])
+		if ref == "" || strings.HasPrefix(ref, "#") || strings.Contains(ref, ":") {
+			continue // same-document anchor or absolute URL (http:, mailto:, data:, ...)
+		}
+
+		ref, _, _ = strings.Cut(ref, "#")
+
+		target := path.Join(path.Dir(docPath), ref)
+		if _, ok := files[target]; !ok {
+			return fmt.Errorf("%w: %s references missing file %s", ErrInvalidEpub, docPath, target)
+		}
+	}
+
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot open %s: %w", ErrInvalidEpub, file.Name, err)
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func unmarshalZipFile(file *zip.File, v interface{}) error {
+	content, err := readZipFile(file)
+	if err != nil {
+		return err
+	}
+
+	if err := xml.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%w: cannot parse %s: %w", ErrInvalidEpub, file.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package epub
+
+import (
+	"fmt"
+
+	"github.com/coghost/toolbox/pathlib"
+)
+
+func (s *EBookSuite) TestValidate() {
+	empty, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().ErrorIs(empty.Validate(), ErrInvalidEpub)
+
+	book, err := NewEBook("book", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(book.AddSectionByFile("Chapter One", []string{"paragraph"}))
+	s.Require().NoError(book.Validate())
+
+	// a 1x1 transparent GIF
+	image := pathlib.Path(s.T().TempDir()).Join("pixel.gif")
+	s.Require().NoError(image.WriteBytes([]byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\xff\xff\xff!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")))
+
+	imagePath, err := book.Epub.AddImage(image.String(), "")
+	s.Require().NoError(err)
+
+	_, err = book.AddSection("Figures", fmt.Sprintf(`<h1>Figures</h1><img src="%s" alt="pixel"/>`, imagePath))
+	s.Require().NoError(err)
+	s.Require().NoError(book.Validate())
+
+	_, err = book.AddSection("Broken", `<h1>Broken</h1><img src="../images/missing.png" alt="missing"/>`)
+	s.Require().NoError(err)
+	s.Require().ErrorIs(book.Validate(), ErrInvalidEpub)
+
+	untitled, err := NewEBook("", "author")
+	s.Require().NoError(err)
+	s.Require().NoError(untitled.AddSectionByFile("Chapter", nil))
+	s.Require().ErrorIs(untitled.Validate(), ErrInvalidEpub)
+}
@@ -7,6 +7,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/matcornic/hermes/v2 v2.1.0
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/time v0.6.0
 )
 
 require (
@@ -1,14 +1,20 @@
 package mail
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"html/template"
 	"log"
+	"net/mail"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/coghost/xmail"
 	"github.com/matcornic/hermes/v2"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -25,11 +31,23 @@ const (
 	Unknown      = "[✘] Unknown"
 )
 
+// ErrRateLimited is returned by a fail-fast rate-limited Mailer when a send would exceed the limit.
+var ErrRateLimited = errors.New("mail rate limit exceeded")
+
+// ErrNilTemplate is returned by NotifyTemplate when no template is given.
+var ErrNilTemplate = errors.New("mail template is nil")
+
 // Mailer represents an email client with configuration and mocking capabilities
 type Mailer struct {
 	mock bool
 
 	serverCfg xmail.MailCfg
+
+	// newService creates the sender for a config, xmail.GenMailService when nil.
+	newService func(cfg *xmail.MailCfg) xmail.IMail
+
+	limiter  *rate.Limiter
+	failFast bool
 }
 
 // MAIL is the exported mail client
@@ -40,6 +58,47 @@ func (m *Mailer) Mock(b bool) {
 	m.mock = b
 }
 
+// WithRateLimit limits the Mailer to n messages per the given period, using a token bucket
+// that allows bursts of up to n messages. Each Mailer has its own limiter, so different
+// accounts are throttled independently. The limit also applies in mock mode.
+//
+// By default a send waits until the limiter allows it; with failFast set to true it returns
+// ErrRateLimited instead. A non-positive n or period removes the limit.
+//
+// Example:
+//
+//	// at most 10 mails per minute, waiting when alerts burst
+//	MAIL.WithRateLimit(10, time.Minute)
+func (m *Mailer) WithRateLimit(n int, per time.Duration, failFast ...bool) *Mailer {
+	if n <= 0 || per <= 0 {
+		m.limiter = nil
+		return m
+	}
+
+	m.limiter = rate.NewLimiter(rate.Every(per/time.Duration(n)), n)
+	m.failFast = len(failFast) > 0 && failFast[0]
+
+	return m
+}
+
+// wait blocks until the rate limiter allows one more message,
+// or returns ErrRateLimited in fail-fast mode.
+func (m *Mailer) wait() error {
+	if m.limiter == nil {
+		return nil
+	}
+
+	if m.failFast {
+		if !m.limiter.Allow() {
+			return ErrRateLimited
+		}
+
+		return nil
+	}
+
+	return m.limiter.Wait(context.Background())
+}
+
 // SetupServer configures the mail server settings
 // server: The type of mail server ("gmail" or "exmail")
 // sendTo: A slice of recipient email addresses
@@ -93,6 +152,10 @@ func (m *Mailer) Notify(subject, body string) error {
 		return e
 	}
 
+	if err := m.wait(); err != nil {
+		return err
+	}
+
 	if m.mock {
 		log.Println(subject)
 		log.Println(htmlBody)
@@ -100,11 +163,149 @@ func (m *Mailer) Notify(subject, body string) error {
 		return nil
 	}
 
-	s := xmail.GenMailService(&m.serverCfg)
+	s := m.service(&m.serverCfg)
 
 	return s.Notify(subject, htmlBody)
 }
 
+// NotifyTemplate sends an email whose body is produced by executing tmpl with data, so the
+// content structure lives in the template instead of being formatted by the caller.
+//
+// The rendered HTML is sent as is, without the Hermes layout Notify wraps the body in; the
+// template decides the whole document. Values are escaped by html/template as usual.
+// In mock mode the subject and the rendered body are logged instead of sent.
+//
+// Parameters:
+//   - subject: The subject hint, the hostname is appended like in Notify
+//   - tmpl: The html/template to execute
+//   - data: The data passed to the template
+//
+// Returns:
+//   - error: ErrNilTemplate, an invalid server config, a template execution error,
+//     a rate limit error, or the send error
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("job").Parse(`<p>{{.Job}} finished in {{.Took}}</p>`))
+//	err := MAIL.NotifyTemplate(EmailDone, tmpl, map[string]any{"Job": "backup", "Took": "3m"})
+func (m *Mailer) NotifyTemplate(subject string, tmpl *template.Template, data interface{}) error {
+	if tmpl == nil {
+		return ErrNilTemplate
+	}
+
+	if err := xmail.VerifyConfig(&m.serverCfg); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("cannot render mail template: %w", err)
+	}
+
+	subject = genSubject(subject)
+	htmlBody := buf.String()
+
+	if err := m.wait(); err != nil {
+		return err
+	}
+
+	if m.mock {
+		log.Println(subject)
+		log.Println(htmlBody)
+
+		return nil
+	}
+
+	return m.service(&m.serverCfg).Notify(subject, htmlBody)
+}
+
+// NotifyEach sends the notification as a separate message to each recipient, so a
+// malformed or rejected address doesn't prevent delivery to the others.
+//
+// It returns the send error of every recipient, keyed by address, with nil for a successful
+// send. Addresses that fail to parse are reported without attempting a send. In mock mode
+// nothing is sent and every recipient maps to nil. If the server config is invalid or the
+// body cannot be generated, every recipient maps to that error.
+//
+// Example:
+//
+//	for addr, err := range MAIL.NotifyEach(EmailAlert, "disk almost full") {
+//	    if err != nil {
+//	        log.Printf("cannot notify %s: %v", addr, err)
+//	    }
+//	}
+func (m *Mailer) NotifyEach(subject, body string) map[string]error {
+	results := make(map[string]error, len(m.serverCfg.To))
+
+	failAll := func(err error) map[string]error {
+		for _, to := range m.serverCfg.To {
+			results[to] = err
+		}
+
+		return results
+	}
+
+	if err := xmail.VerifyConfig(&m.serverCfg); err != nil {
+		return failAll(err)
+	}
+
+	subject = genSubject(subject)
+
+	htmlBody, err := genHTMLBody(EmailDone, body)
+	if err != nil {
+		return failAll(err)
+	}
+
+	for _, to := range m.serverCfg.To {
+		if err := m.wait(); err != nil {
+			results[to] = err
+			continue
+		}
+
+		if m.mock {
+			log.Printf("%s -> %s", subject, to)
+			results[to] = nil
+
+			continue
+		}
+
+		if _, err := mail.ParseAddress(to); err != nil {
+			results[to] = fmt.Errorf("invalid address %q: %w", to, err)
+			continue
+		}
+
+		cfg := m.serverCfg
+		cfg.To = []string{to}
+
+		results[to] = m.service(&cfg).Notify(subject, htmlBody)
+	}
+
+	return results
+}
+
+func (m *Mailer) service(cfg *xmail.MailCfg) xmail.IMail {
+	if m.newService != nil {
+		return m.newService(cfg)
+	}
+
+	return xmail.GenMailService(cfg)
+}
+
+// RenderHTML returns the HTML email body for the given title and body, generated exactly
+// like Notify does (Notify uses EmailDone as the title), without sending anything.
+// It is useful to preview or snapshot-test alert formatting.
+//
+// Example:
+//
+//	html, err := MAIL.RenderHTML(EmailAlert, "disk almost full")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("preview.html", []byte(html), 0o644)
+func (m *Mailer) RenderHTML(title, body string) (string, error) {
+	return genHTMLBody(title, body)
+}
+
 // genSubject generates the email subject with a given hint and the hostname
 func genSubject(hint string) string {
 	return fmt.Sprintf("%s: HOST %s", hint, hostname())
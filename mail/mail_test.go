@@ -1,10 +1,12 @@
 package mail
 
 import (
-	"log"
+	"errors"
+	"html/template"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/coghost/xmail"
 	"github.com/joho/godotenv"
@@ -20,9 +22,9 @@ func TestMail(t *testing.T) {
 }
 
 func (s *MailSuite) SetupSuite() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	// sending real mail needs SMTP credentials, the offline suites run without them
+	if err := godotenv.Load(); err != nil {
+		s.T().Skipf("skipping SMTP tests, cannot load .env: %v", err)
 	}
 }
 
@@ -35,3 +37,137 @@ func (s *MailSuite) TestNotify() {
 	err := MAIL.Notify(EmailDone, "test body")
 	s.Nil(err)
 }
+
+// fakeService records the recipients it sends to and fails for the configured ones.
+type fakeService struct {
+	cfg  *xmail.MailCfg
+	fail map[string]error
+	sent *[]string
+	// bodies records the sent bodies when set.
+	bodies *[]string
+}
+
+func (f fakeService) Notify(subject, body string) error {
+	to := f.cfg.To[0]
+	if err := f.fail[to]; err != nil {
+		return err
+	}
+
+	*f.sent = append(*f.sent, to)
+
+	if f.bodies != nil {
+		*f.bodies = append(*f.bodies, body)
+	}
+
+	return nil
+}
+
+// NotifyEachSuite runs without SMTP credentials, using mock mode and a fake sender.
+type NotifyEachSuite struct {
+	suite.Suite
+}
+
+func TestNotifyEach(t *testing.T) {
+	suite.Run(t, new(NotifyEachSuite))
+}
+
+func (s *NotifyEachSuite) TestMock() {
+	mailer := &Mailer{}
+	mailer.SetupServer(xmail.GmailServer, []string{"a@example.com", "not-an-address"})
+	mailer.Mock(true)
+
+	s.Equal(map[string]error{"a@example.com": nil, "not-an-address": nil}, mailer.NotifyEach(EmailAlert, "body"))
+}
+
+func (s *NotifyEachSuite) TestFakeSender() {
+	errRejected := errors.New("550 mailbox unavailable")
+
+	var sent []string
+
+	mailer := &Mailer{}
+	mailer.SetupServer(xmail.GmailServer, []string{"a@example.com", "not-an-address", "b@example.com", "gone@example.com"})
+	mailer.newService = func(cfg *xmail.MailCfg) xmail.IMail {
+		return fakeService{cfg: cfg, fail: map[string]error{"gone@example.com": errRejected}, sent: &sent}
+	}
+
+	results := mailer.NotifyEach(EmailAlert, "body")
+
+	s.Len(results, 4)
+	s.NoError(results["a@example.com"])
+	s.NoError(results["b@example.com"])
+	s.Error(results["not-an-address"])
+	s.ErrorIs(results["gone@example.com"], errRejected)
+	s.Equal([]string{"a@example.com", "b@example.com"}, sent)
+
+	noServer := &Mailer{}
+	s.Empty(noServer.NotifyEach(EmailAlert, "body"))
+}
+
+// OfflineMailSuite covers rate limiting and rendering without SMTP credentials.
+type OfflineMailSuite struct {
+	suite.Suite
+}
+
+func TestOfflineMail(t *testing.T) {
+	suite.Run(t, new(OfflineMailSuite))
+}
+
+func (s *OfflineMailSuite) TestWithRateLimit() {
+	mailer := &Mailer{}
+	mailer.SetupServer(xmail.GmailServer, []string{"a@example.com"})
+	mailer.Mock(true)
+	mailer.WithRateLimit(1, 50*time.Millisecond)
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		s.Require().NoError(mailer.Notify(EmailDone, "body"))
+	}
+
+	// the first mail uses the burst, the next two wait 50ms each
+	s.GreaterOrEqual(time.Since(start), 90*time.Millisecond)
+
+	mailer.WithRateLimit(1, time.Hour, true)
+	s.Require().NoError(mailer.Notify(EmailDone, "body"))
+	s.Require().ErrorIs(mailer.Notify(EmailDone, "body"), ErrRateLimited)
+
+	other := &Mailer{}
+	other.SetupServer(xmail.GmailServer, []string{"a@example.com"})
+	other.Mock(true)
+	s.Require().NoError(other.WithRateLimit(1, time.Hour, true).Notify(EmailDone, "body"), "limits are per Mailer")
+}
+
+func (s *OfflineMailSuite) TestRenderHTML() {
+	html, err := (&Mailer{}).RenderHTML(EmailAlert, "disk almost full on /data")
+	s.Require().NoError(err)
+
+	s.Contains(html, EmailAlert)
+	s.Contains(html, "disk almost full on /data")
+	s.Contains(html, "<html")
+}
+
+func (s *OfflineMailSuite) TestNotifyTemplate() {
+	var sent, bodies []string
+
+	mailer := &Mailer{}
+	mailer.SetupServer(xmail.GmailServer, []string{"a@example.com"})
+	mailer.newService = func(cfg *xmail.MailCfg) xmail.IMail {
+		return fakeService{cfg: cfg, sent: &sent, bodies: &bodies}
+	}
+
+	tmpl := template.Must(template.New("job").Parse(`<p>{{.Job}} finished in {{.Took}}</p>`))
+
+	s.Require().NoError(mailer.NotifyTemplate(EmailDone, tmpl, map[string]string{"Job": "backup<db>", "Took": "3m"}))
+	s.Equal([]string{"a@example.com"}, sent)
+	s.Require().Len(bodies, 1)
+	s.Equal("<p>backup&lt;db&gt; finished in 3m</p>", bodies[0])
+
+	mailer.Mock(true)
+	s.NoError(mailer.NotifyTemplate(EmailDone, tmpl, map[string]string{"Job": "backup", "Took": "1s"}))
+	s.Len(bodies, 1)
+
+	s.ErrorIs(mailer.NotifyTemplate(EmailDone, nil, nil), ErrNilTemplate)
+
+	broken := template.Must(template.New("broken").Parse(`{{.Missing.Field}}`))
+	s.Error(mailer.NotifyTemplate(EmailDone, broken, struct{}{}))
+}
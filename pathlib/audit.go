@@ -0,0 +1,81 @@
+package pathlib
+
+import (
+	"sync/atomic"
+)
+
+// Operation names passed to the audit hook.
+const (
+	AuditWrite  = "write"
+	AuditDelete = "delete"
+	AuditChmod  = "chmod"
+	AuditRename = "rename"
+	AuditRead   = "read"
+)
+
+type auditConfig struct {
+	fn           func(op, path string)
+	includeReads bool
+}
+
+var auditHook atomic.Pointer[auditConfig]
+
+// SetAuditHook registers a package-level callback invoked after every successful mutating
+// FsPath operation, with the operation name and the absolute path it applied to.
+//
+// The operations reported are:
+//   - AuditWrite: SetBytes and the methods built on it (WriteText, WriteBytes, WriteEncoded, ...),
+//     WriteFromReader, the atomic writers, appends, Appender, TeeReader, Touch, the
+//     destination of Copy and the symlinks created by CopyDir; the archives created by ZipDir,
+//     TarGzDir, Compress, ZipFiles and CompressReaderToZip and the files extracted by Unzip,
+//     Untar and Extract; each file a RotatingWriter opens; and the directories MirrorTo creates
+//     and the files whose modification time it sets.
+//   - AuditDelete: Unlink, Rmdir, RmTree and the existing entries CopyDir replaces with symlinks.
+//   - AuditChmod: Chmod.
+//   - AuditRename: Rename and Move, with the source path, and SwapWith, with both paths.
+//
+// Creating directories with MkdirAll, Mkdirs or MkParentDir, including the directories made
+// while extracting an archive, is not reported.
+//
+// Parameters:
+//   - fn: The callback. It is called synchronously, possibly from several goroutines,
+//     so it must be safe for concurrent use. Passing nil removes the hook.
+//   - includeReads: Optional; if true, GetBytes, Reader and ReadSeeker are reported as
+//     AuditRead as well, which covers the Get*/Read* methods built on them. Defaults to false.
+//
+// Example:
+//
+//	SetAuditHook(func(op, path string) {
+//	    logger.Info("file operation", zap.String("op", op), zap.String("path", path))
+//	})
+//	defer SetAuditHook(nil)
+func SetAuditHook(fn func(op, path string), includeReads ...bool) {
+	if fn == nil {
+		auditHook.Store(nil)
+		return
+	}
+
+	auditHook.Store(&auditConfig{
+		fn:           fn,
+		includeReads: len(includeReads) > 0 && includeReads[0],
+	})
+}
+
+// audit reports op on path to the audit hook, if one is set.
+func audit(op, path string) {
+	cfg := auditHook.Load()
+	if cfg == nil || (op == AuditRead && !cfg.includeReads) {
+		return
+	}
+
+	cfg.fn(op, path)
+}
+
+// auditResult reports op on path if err is nil, and returns err unchanged.
+func auditResult(op, path string, err error) error {
+	if err == nil {
+		audit(op, path)
+	}
+
+	return err
+}
@@ -0,0 +1,160 @@
+package pathlib
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+type auditRecorder struct {
+	mu      sync.Mutex
+	entries [][2]string
+}
+
+func (r *auditRecorder) record(op, path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, [2]string{op, path})
+}
+
+func (s *PathSuite) TestSetAuditHook() {
+	recorder := &auditRecorder{}
+	SetAuditHook(recorder.record)
+	defer SetAuditHook(nil)
+
+	file := Path(s.tempDir).Join("audited.txt")
+	s.Require().NoError(file.WriteText(_testContent))
+	s.Equal(_testContent, file.MustReadText())
+	s.Require().NoError(file.Unlink(false))
+	s.Error(file.Unlink(false), "failed operations are not reported")
+
+	s.Equal([][2]string{
+		{AuditWrite, file.String()},
+		{AuditDelete, file.String()},
+	}, recorder.entries)
+
+	recorder.entries = nil
+	SetAuditHook(recorder.record, true)
+	s.Require().NoError(file.WriteText(_testContent))
+	s.Require().NoError(file.Chmod(0o600))
+	s.Equal(_testContent, file.MustReadText())
+
+	s.Equal([][2]string{
+		{AuditWrite, file.String()},
+		{AuditChmod, file.String()},
+		{AuditRead, file.String()},
+	}, recorder.entries)
+
+	recorder.entries = nil
+	SetAuditHook(nil)
+	s.Require().NoError(file.WriteText(_testContent))
+	s.Empty(recorder.entries)
+}
+
+func (s *PathSuite) TestAuditSwapAndCopyDir() {
+	recorder := &auditRecorder{}
+	SetAuditHook(recorder.record)
+	defer SetAuditHook(nil)
+
+	active := Path(s.createTempFile("active.txt", "blue"))
+	standby := Path(s.createTempFile("standby.txt", "green"))
+
+	s.Require().NoError(active.SwapWith(standby))
+	s.Equal([][2]string{
+		{AuditRename, active.String()},
+		{AuditRename, standby.String()},
+	}, recorder.entries)
+
+	memFs := afero.NewMemMapFs()
+	memActive := Path("/mem/active.txt").WithFs(memFs)
+	memStandby := Path("/mem/standby.txt").WithFs(memFs)
+	s.Require().NoError(memActive.WriteText("blue"))
+	s.Require().NoError(memStandby.WriteText("green"))
+
+	recorder.entries = nil
+	s.Require().NoError(memActive.SwapWith(memStandby), "the rename fallback is audited too")
+	s.Equal([][2]string{
+		{AuditRename, memActive.String()},
+		{AuditRename, memStandby.String()},
+	}, recorder.entries)
+
+	src := filepath.Join(s.tempDir, "tree")
+	s.Require().NoError(os.MkdirAll(src, 0o755))
+	s.Require().NoError(os.Symlink("active.txt", filepath.Join(src, "link")))
+
+	dest := filepath.Join(s.tempDir, "tree-copy")
+	s.Require().NoError(os.MkdirAll(dest, 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dest, "link"), []byte("stale"), 0o644))
+
+	recorder.entries = nil
+	s.Require().NoError(Path(src).CopyDir(dest))
+	s.Contains(recorder.entries, [2]string{AuditDelete, filepath.Join(dest, "link")})
+	s.Contains(recorder.entries, [2]string{AuditWrite, filepath.Join(dest, "link")})
+}
+
+func (s *PathSuite) TestAuditArchivesAndWriters() {
+	recorder := &auditRecorder{}
+	SetAuditHook(recorder.record)
+	defer SetAuditHook(nil)
+
+	src := Path(s.tempDir).Join("src")
+	s.Require().NoError(src.Join("a.txt").WriteText("a"))
+
+	recorder.entries = nil
+	zipPath, _, err := src.ZipDir("src.zip")
+	s.Require().NoError(err)
+	tarPath, _, err := src.TarGzDir("src.tar.gz")
+	s.Require().NoError(err)
+
+	files := Path(s.tempDir).Join("files.zip")
+	_, err = ZipFiles(files.String(), []*FsPath{src.Join("a.txt")})
+	s.Require().NoError(err)
+
+	readers := Path(s.tempDir).Join("readers.zip")
+	s.Require().NoError(CompressReaderToZip(map[string]io.Reader{"b.txt": strings.NewReader("b")}, readers.String()))
+
+	unzipDir := Path(s.tempDir).Join("unzipped")
+	s.Require().NoError(zipPath.Unzip(unzipDir.String()))
+	untarDir := Path(s.tempDir).Join("untarred")
+	s.Require().NoError(tarPath.Untar(untarDir.String()))
+
+	for _, written := range []*FsPath{zipPath, tarPath, files, readers, unzipDir.Join("src", "a.txt"), untarDir.Join("src", "a.txt")} {
+		s.Contains(recorder.entries, [2]string{AuditWrite, written.String()})
+	}
+
+	recorder.entries = nil
+	log := Path(s.tempDir).Join("logs", "app.log")
+	w := NewRotatingWriter(log, 1)
+	s.Require().NoError(w.WriteLine("one"))
+	s.Require().NoError(w.WriteLine("two"))
+	s.Require().NoError(w.Close())
+	s.Contains(recorder.entries, [2]string{AuditWrite, log.String()})
+	s.Contains(recorder.entries, [2]string{AuditRename, log.String()})
+
+	s.Require().NoError(src.Join("sub", "c.txt").WriteText("c"))
+
+	recorder.entries = nil
+	mirror := Path(s.tempDir).Join("mirror")
+	_, err = src.MirrorTo(mirror.String(), MirrorOptions{})
+	s.Require().NoError(err)
+	s.Contains(recorder.entries, [2]string{AuditWrite, mirror.Join("sub").String()})
+	s.Equal(2, countEntries(recorder.entries, [2]string{AuditWrite, mirror.Join("sub", "c.txt").String()}),
+		"the copy and the modification time are both reported")
+}
+
+func countEntries(entries [][2]string, want [2]string) int {
+	count := 0
+
+	for _, entry := range entries {
+		if entry == want {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,111 @@
+package pathlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// Unmarshal reads the file and decodes it into v, choosing the decoder from the file suffix:
+// ".json" for JSON, ".yaml" or ".yml" for YAML and ".toml" for TOML. The suffix is matched
+// case-insensitively.
+//
+// Parameters:
+//   - v: A pointer to the value to decode into.
+//
+// Returns:
+//   - error: An error wrapping ErrUnsupportedFormat for any other suffix, or an error
+//     if the file cannot be read or decoded.
+//
+// Example:
+//
+//	type Config struct {
+//	    Name string `json:"name" yaml:"name" toml:"name"`
+//	}
+//
+//	var cfg Config
+//	err := Path(configFile).Unmarshal(&cfg) // works for config.json, config.yaml and config.toml
+func (p *FsPath) Unmarshal(v interface{}) error {
+	format, err := p.codecFormat()
+	if err != nil {
+		return err
+	}
+
+	data, err := p.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		err = toml.Unmarshal(data, v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", p.absPath, err)
+	}
+
+	return nil
+}
+
+// Marshal encodes v in the format given by the file suffix, like Unmarshal, and atomically
+// replaces the file with the result. JSON is written indented with two spaces.
+//
+// Parameters:
+//   - v: The value to encode.
+//
+// Returns:
+//   - error: An error wrapping ErrUnsupportedFormat for an unknown suffix, or an error
+//     if encoding or writing fails.
+//
+// Example:
+//
+//	err := Path("/etc/app/config.toml").Marshal(cfg)
+func (p *FsPath) Marshal(v interface{}) error {
+	format, err := p.codecFormat()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+
+	switch format {
+	case ".json":
+		data, err = json.MarshalIndent(v, "", "  ")
+	case ".yaml":
+		data, err = yaml.Marshal(v)
+	default:
+		var buf bytes.Buffer
+		err = toml.NewEncoder(&buf).Encode(v)
+		data = buf.Bytes()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", p.absPath, err)
+	}
+
+	return p.writeFileAtomic(data)
+}
+
+// codecFormat returns the normalized format for the file suffix: ".json", ".yaml" or ".toml".
+func (p *FsPath) codecFormat() (string, error) {
+	switch suffix := strings.ToLower(p.Suffix); suffix {
+	case ".json", ".toml":
+		return suffix, nil
+	case ".yaml", ".yml":
+		return ".yaml", nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, p.Suffix)
+	}
+}
@@ -0,0 +1,47 @@
+package pathlib
+
+type codecConfig struct {
+	Name    string   `json:"name" yaml:"name" toml:"name"`
+	Version int      `json:"version" yaml:"version" toml:"version"`
+	Tags    []string `json:"tags" yaml:"tags" toml:"tags"`
+}
+
+func (s *PathSuite) TestUnmarshal() {
+	want := codecConfig{Name: "toolbox", Version: 2, Tags: []string{"a", "b"}}
+
+	files := map[string]string{
+		"config.json": `{"name": "toolbox", "version": 2, "tags": ["a", "b"]}`,
+		"config.yaml": "name: toolbox\nversion: 2\ntags:\n  - a\n  - b\n",
+		"config.YML":  "name: toolbox\nversion: 2\ntags: [a, b]\n",
+		"config.toml": "name = \"toolbox\"\nversion = 2\ntags = [\"a\", \"b\"]\n",
+	}
+
+	for name, content := range files {
+		s.Run(name, func() {
+			var got codecConfig
+			s.Require().NoError(Path(s.createTempFile(name, content)).Unmarshal(&got))
+			s.Equal(want, got)
+		})
+	}
+
+	var got codecConfig
+	s.ErrorIs(Path(s.createTempFile("config.ini", "name=toolbox")).Unmarshal(&got), ErrUnsupportedFormat)
+	s.Error(Path(s.createTempFile("broken.json", "{")).Unmarshal(&got))
+}
+
+func (s *PathSuite) TestMarshal() {
+	want := codecConfig{Name: "toolbox", Version: 2, Tags: []string{"a", "b"}}
+
+	for _, name := range []string{"out.json", "out.yaml", "out.toml"} {
+		s.Run(name, func() {
+			file := Path(s.tempDir).Join("marshal", name)
+			s.Require().NoError(file.Marshal(want))
+
+			var got codecConfig
+			s.Require().NoError(file.Unmarshal(&got))
+			s.Equal(want, got)
+		})
+	}
+
+	s.ErrorIs(Path(s.tempDir).Join("out.txt").Marshal(want), ErrUnsupportedFormat)
+}
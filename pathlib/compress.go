@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -15,23 +17,63 @@ import (
 
 const (
 	maxSize = 1 * 1024 * 1024 * 1024 // 1GB max size
+	// maxRatio is far above what real text or log archives reach, while deflate bombs sit near its ~1032x ceiling.
+	maxRatio = 1000
+	// ratioCheckMinSize exempts small entries, e.g. a few KB of padding, from the ratio guard.
+	ratioCheckMinSize = 64 * 1024
+	// gzipReadAhead is the bufio buffer gzip.NewReader reads the compressed stream through.
+	gzipReadAhead = 4096
 )
 
 var (
-	ErrIllegalFilePath = errors.New("illegal file path")
-	ErrFileTooLarge    = errors.New("file exceeded maximum allowed size")
-	ErrIncompleteWrite = errors.New("incomplete write: consider increasing the maxSize parameter or checking for disk space issues")
+	ErrIllegalFilePath    = errors.New("illegal file path")
+	ErrFileTooLarge       = errors.New("file exceeded maximum allowed size")
+	ErrIncompleteWrite    = errors.New("incomplete write: consider increasing the maxSize parameter or checking for disk space issues")
+	ErrDuplicateEntry     = errors.New("duplicate archive entry")
+	ErrArchiveTooLarge    = errors.New("archive exceeded maximum allowed total size")
+	ErrTooManyFiles       = errors.New("archive exceeded maximum allowed number of files")
+	ErrRatioTooHigh       = errors.New("compression ratio exceeded maximum allowed: possible decompression bomb")
+	ErrUnsupportedArchive = errors.New("unsupported archive type")
+	ErrUnknownCollision   = errors.New("unknown collision strategy")
+)
+
+// CollisionStrategy decides what ZipFiles does when two files map to the same entry name.
+type CollisionStrategy int
+
+const (
+	// CollisionRename keeps both files, renaming later ones to "name (1).ext", "name (2).ext", ...
+	CollisionRename CollisionStrategy = iota
+	// CollisionSkip keeps the first file and silently skips later ones.
+	CollisionSkip
+	// CollisionError aborts with ErrDuplicateEntry.
+	CollisionError
 )
 
 // CompressOptions holds the options for compression and decompression operations
 type CompressOptions struct {
 	MaxSize int64
+	// MaxTotalSize caps the cumulative size of all extracted files. 0 means unlimited.
+	MaxTotalSize int64
+	// MaxFiles caps the number of extracted files. 0 means unlimited.
+	MaxFiles int
+	// MaxRatio caps the uncompressed/compressed size ratio of each extracted entry.
+	// Defaults to 1000; 0 or less disables the check.
+	MaxRatio float64
+	// Collision is the strategy ZipFiles uses for duplicate entry names.
+	Collision CollisionStrategy
+	// EntryName maps a file to its entry path inside the archive for ZipFiles.
+	// Defaults to the file's base name.
+	EntryName func(file *FsPath) string
+	// CheckDiskSpace makes Unzip fail fast with ErrInsufficientSpace when the archive's
+	// uncompressed size exceeds the space available at the destination.
+	CheckDiskSpace bool
 }
 
 // defaultCompressOptions returns the default options for compression and decompression
 func defaultCompressOptions() CompressOptions {
 	return CompressOptions{
-		MaxSize: maxSize,
+		MaxSize:  maxSize,
+		MaxRatio: maxRatio,
 	}
 }
 
@@ -45,6 +87,51 @@ func WithMaxSize(maxSize int64) CompressOption {
 	}
 }
 
+// WithMaxTotalSize sets the MaxTotalSize option
+func WithMaxTotalSize(maxTotalSize int64) CompressOption {
+	return func(o *CompressOptions) {
+		o.MaxTotalSize = maxTotalSize
+	}
+}
+
+// WithMaxFiles sets the MaxFiles option
+func WithMaxFiles(maxFiles int) CompressOption {
+	return func(o *CompressOptions) {
+		o.MaxFiles = maxFiles
+	}
+}
+
+// WithMaxRatio sets the decompression bomb guard: Unzip and Untar fail with ErrRatioTooHigh
+// when an entry expands to more than maxRatio times its compressed size. Entries smaller than
+// 64KB are never rejected. The default of 1000 is far above what real text or log archives
+// reach, while deflate bombs sit near the ~1032x ceiling of the format. Pass 0 to disable it.
+func WithMaxRatio(maxRatio float64) CompressOption {
+	return func(o *CompressOptions) {
+		o.MaxRatio = maxRatio
+	}
+}
+
+// WithCollisionStrategy sets how ZipFiles handles duplicate entry names
+func WithCollisionStrategy(strategy CollisionStrategy) CompressOption {
+	return func(o *CompressOptions) {
+		o.Collision = strategy
+	}
+}
+
+// WithEntryName sets the function ZipFiles uses to name each archive entry
+func WithEntryName(fn func(file *FsPath) string) CompressOption {
+	return func(o *CompressOptions) {
+		o.EntryName = fn
+	}
+}
+
+// WithDiskSpaceCheck enables the free space check before extraction
+func WithDiskSpaceCheck() CompressOption {
+	return func(o *CompressOptions) {
+		o.CheckDiskSpace = true
+	}
+}
+
 func applyCompressOptions(opts ...CompressOption) CompressOptions {
 	options := defaultCompressOptions()
 	for _, opt := range opts {
@@ -96,11 +183,7 @@ func (p *FsPath) ZipDir(zipFileName string) (*FsPath, int, error) {
 	zipWriter := zip.NewWriter(zipFile)
 	defer zipWriter.Close()
 
-	totalFiles, err := p.compressDirectoryToWriter(
-		func(name string, info os.FileInfo) (io.Writer, error) {
-			return zipWriter.Create(name)
-		},
-	)
+	totalFiles, err := p.compressDirectoryToWriter(zipWriterFactory(zipWriter))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -108,6 +191,167 @@ func (p *FsPath) ZipDir(zipFileName string) (*FsPath, int, error) {
 	return zipPath, totalFiles, nil
 }
 
+// ZipFiles creates a zip archive at dest containing the given files, which may live in
+// different directories. It is the file-list complement to ZipDir.
+//
+// Each file is stored under its base name unless WithEntryName is given. When two files
+// map to the same entry name, the collision strategy (WithCollisionStrategy) decides:
+// CollisionRename (default) stores later files as "name (1).ext", "name (2).ext", ...;
+// CollisionSkip keeps only the first; CollisionError aborts with ErrDuplicateEntry.
+// Any other strategy value is rejected with ErrUnknownCollision before dest is created.
+// If adding a file or finalizing the archive fails, the partial archive is removed.
+//
+// Parameters:
+//   - dest: The path of the zip file to create. An existing file is overwritten.
+//   - files: The files to add. Directories are not supported.
+//   - opts: Optional CompressOption values.
+//
+// Returns:
+//   - int: The number of files written to the archive, or 0 on error.
+//   - error: An error if the archive cannot be created or a file cannot be added.
+//
+// Example usage:
+//
+//	files := []*FsPath{Path("/var/log/app.log"), Path("/etc/app/config.yaml")}
+//	n, err := ZipFiles("/tmp/bundle.zip", files)
+//	if err != nil {
+//	    log.Fatalf("Failed to create zip: %v", err)
+//	}
+//	fmt.Printf("Zipped %d files\n", n)
+func ZipFiles(dest string, files []*FsPath, opts ...CompressOption) (int, error) {
+	options := applyCompressOptions(opts...)
+
+	switch options.Collision {
+	case CollisionRename, CollisionSkip, CollisionError:
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrUnknownCollision, options.Collision)
+	}
+
+	entryName := options.EntryName
+	if entryName == nil {
+		entryName = func(file *FsPath) string { return file.Name }
+	}
+
+	destPath := Path(dest)
+
+	zipFile, err := destPath.fs.Create(destPath.absPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create .zip file: %w", err)
+	}
+
+	// cleanup removes the partially written archive, so a failure never leaves one behind.
+	cleanup := func(err error) (int, error) {
+		zipFile.Close()
+		_ = destPath.fs.Remove(destPath.absPath)
+
+		return 0, err
+	}
+
+	zipWriter := zip.NewWriter(zipFile)
+	createWriter := zipWriterFactory(zipWriter)
+
+	used := make(map[string]bool, len(files))
+	totalFiles := 0
+
+	for _, file := range files {
+		name := filepath.ToSlash(entryName(file))
+
+		if used[name] {
+			switch options.Collision {
+			case CollisionSkip:
+				continue
+			case CollisionError:
+				return cleanup(fmt.Errorf("%w: %s", ErrDuplicateEntry, name))
+			case CollisionRename:
+				name = uniqueName(name, func(candidate string) bool { return used[candidate] })
+			}
+		}
+
+		used[name] = true
+
+		if err := file.copyToArchive(name, createWriter); err != nil {
+			return cleanup(err)
+		}
+
+		totalFiles++
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return cleanup(fmt.Errorf("failed to finalize zip: %w", err))
+	}
+
+	if err := zipFile.Close(); err != nil {
+		return cleanup(err)
+	}
+
+	audit(AuditWrite, destPath.absPath)
+
+	return totalFiles, nil
+}
+
+// CompressReaderToZip creates a zip archive at dest from in-memory or piped content,
+// without needing the files on disk first.
+//
+// Each reader is streamed into its entry with io.Copy, never buffered as a whole.
+// Entries are written in sorted name order so the archive layout is deterministic.
+//
+// Parameters:
+//   - entries: Maps entry names (slash-separated paths inside the archive) to their content.
+//   - dest: The path of the zip file to create. An existing file is overwritten.
+//
+// Returns:
+//   - error: An error if the archive cannot be created or a reader fails.
+//
+// Example usage:
+//
+//	err := CompressReaderToZip(map[string]io.Reader{
+//	    "report.csv":  csvReader,
+//	    "logs/run.log": resp.Body,
+//	}, "/tmp/export.zip")
+func CompressReaderToZip(entries map[string]io.Reader, dest string) error {
+	destPath := Path(dest)
+
+	zipFile, err := destPath.fs.Create(destPath.absPath)
+	if err != nil {
+		return fmt.Errorf("failed to create .zip file: %w", err)
+	}
+	defer zipFile.Close()
+
+	audit(AuditWrite, destPath.absPath)
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	zipWriter := zip.NewWriter(zipFile)
+
+	for _, name := range names {
+		writer, err := zipWriter.Create(filepath.ToSlash(name))
+		if err != nil {
+			return fmt.Errorf("failed to create writer for %s: %w", name, err)
+		}
+
+		if _, err := io.Copy(writer, entries[name]); err != nil {
+			return fmt.Errorf("failed to write entry %s: %w", name, err)
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finalize zip: %w", err)
+	}
+
+	return nil
+}
+
+func zipWriterFactory(zipWriter *zip.Writer) writerFactory {
+	return func(name string, info os.FileInfo) (io.Writer, error) {
+		return zipWriter.Create(name)
+	}
+}
+
 // TarGzDir compresses the directory represented by this FsPath into a tar.gz file.
 // The tar.gz file is created in the same folder as the directory.
 //
@@ -146,16 +390,67 @@ func (p *FsPath) ZipDir(zipFileName string) (*FsPath, int, error) {
 // Note: This function compresses the entire directory structure, including subdirectories.
 // Empty directories are included in the archive.
 func (p *FsPath) TarGzDir(tarGzFileName string) (*FsPath, int, error) {
-	tarGzPath, file, err := p.prepareCompression(tarGzFileName, ".tar.gz")
+	return p.tarDir(tarGzFileName, ".tar.gz", true)
+}
+
+// Compress compresses the directory represented by this FsPath into an archive created
+// in the same folder as the directory, choosing the format from archiveName's suffix:
+//   - ".zip": ZipDir
+//   - ".tar.gz", ".tgz": TarGzDir
+//   - ".tar": uncompressed tar
+//
+// Suffixes are matched case-insensitively; an unknown suffix is an error rather than a default format.
+// It is the counterpart of Extract.
+//
+// Parameters:
+//   - archiveName: The name of the archive to create, including its suffix.
+//   - opts: Optional CompressOption values. They are accepted for symmetry with Extract;
+//     the current options only affect extraction.
+//
+// Returns:
+//   - *FsPath: A new FsPath representing the created archive.
+//   - int: The total number of files compressed into the archive.
+//   - error: An error wrapping ErrUnsupportedArchive for an unknown suffix,
+//     or any error returned by the format-specific compression.
+//
+// Example usage:
+//
+//	archive, count, err := Path("/data/reports").Compress("reports.tgz")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Created %s with %d files\n", archive, count)
+func (p *FsPath) Compress(archiveName string, opts ...CompressOption) (*FsPath, int, error) {
+	switch suffix := archiveSuffix(archiveName); suffix {
+	case ".zip":
+		return p.ZipDir(archiveName)
+	case ".tar.gz", ".tgz":
+		return p.tarDir(archiveName, suffix, true)
+	case ".tar":
+		return p.tarDir(archiveName, suffix, false)
+	default:
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnsupportedArchive, archiveName)
+	}
+}
+
+// tarDir writes the directory as a tar archive, gzip-compressed if gzipped is true.
+func (p *FsPath) tarDir(fileName, extension string, gzipped bool) (*FsPath, int, error) {
+	tarPath, file, err := p.prepareCompression(fileName, extension)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer file.Close()
 
-	gzipWriter := gzip.NewWriter(file)
-	defer gzipWriter.Close()
+	var output io.Writer = file
+
+	if gzipped {
+		gzipWriter := gzip.NewWriter(file)
+		defer gzipWriter.Close()
+
+		output = gzipWriter
+	}
 
-	tarWriter := tar.NewWriter(gzipWriter)
+	tarWriter := tar.NewWriter(output)
 	defer tarWriter.Close()
 
 	totalFiles, err := p.compressDirectoryToWriter(
@@ -178,26 +473,91 @@ func (p *FsPath) TarGzDir(tarGzFileName string) (*FsPath, int, error) {
 		return nil, 0, err
 	}
 
-	return tarGzPath, totalFiles, nil
+	return tarPath, totalFiles, nil
+}
+
+// archiveSuffixes lists the archive suffixes recognized by Extract, longest first.
+var archiveSuffixes = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// archiveSuffix returns the archive suffix of name, matched case-insensitively, or "" if unknown.
+func archiveSuffix(name string) string {
+	lower := strings.ToLower(name)
+
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return suffix
+		}
+	}
+
+	return ""
+}
+
+// archiveStem returns name without its archive suffix, e.g. "data" for "data.tar.gz".
+// It names the subdirectory archives are extracted into.
+func archiveStem(name string) string {
+	return name[:len(name)-len(archiveSuffix(name))]
 }
 
-// Untar extracts the contents of the tar file to the specified destination directory.
+// Extract extracts the archive into a subdirectory of destDir named after the archive
+// (e.g. "data.tgz" is extracted into destDir/data), choosing the format from the suffix:
+//   - ".zip": Unzip
+//   - ".tar.gz", ".tgz": Untar
+//   - ".tar": uncompressed tar
+//
+// Suffixes are matched case-insensitively. All CompressOption values apply to every format.
+//
+// Parameters:
+//   - destDir: The directory to extract into.
+//   - opts: Optional CompressOption values, e.g. WithMaxSize or WithMaxTotalSize.
+//
+// Returns:
+//   - error: An error wrapping ErrUnsupportedArchive for any other suffix,
+//     or any error returned by the format-specific extraction.
+//
+// Example usage:
+//
+//	for _, archive := range downloads {
+//	    if err := archive.Extract("/tmp/unpacked"); err != nil {
+//	        log.Printf("skipping %s: %v", archive, err)
+//	    }
+//	}
+func (p *FsPath) Extract(destDir string, opts ...CompressOption) error {
+	switch archiveSuffix(p.Name) {
+	case ".zip":
+		return p.Unzip(destDir, opts...)
+	case ".tar.gz", ".tgz":
+		return p.untar(destDir, true, opts...)
+	case ".tar":
+		return p.untar(destDir, false, opts...)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedArchive, p.Name)
+	}
+}
+
+// Untar extracts the contents of the tar.gz file to the specified destination directory.
 func (p *FsPath) Untar(destDir string, opts ...CompressOption) error {
+	return p.untar(destDir, true, opts...)
+}
+
+func (p *FsPath) untar(destDir string, gzipped bool, opts ...CompressOption) error {
 	options := applyCompressOptions(opts...)
 
 	// Create the subdirectory for extraction
-	subDir := Path(destDir).Join(strings.TrimSuffix(p.Name, ".tar.gz"))
-	if err := subDir.MkdirAll(_mode755); err != nil {
+	subDir := Path(destDir).Join(archiveStem(p.Name))
+	if err := subDir.MkdirAll(DefaultDirMode); err != nil {
 		return fmt.Errorf("failed to create subdirectory: %w", err)
 	}
 
 	// Prepare the tar reader
-	tarReader, cleanup, err := p.prepareUntarEnvironment()
+	tarReader, compressed, cleanup, err := p.prepareUntarEnvironment(gzipped)
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	budget := newExtractionBudget(options)
+	guard := &ratioGuard{compressed: compressed, maxRatio: options.MaxRatio}
+
 	for {
 		header, err := tarReader.Next()
 
@@ -210,7 +570,10 @@ func (p *FsPath) Untar(destDir string, opts ...CompressOption) error {
 			continue
 		}
 
-		target := subDir.Join(header.Name)
+		target, err := subDir.SafeJoin(header.Name)
+		if err != nil {
+			return err
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -218,11 +581,17 @@ func (p *FsPath) Untar(destDir string, opts ...CompressOption) error {
 				return err
 			}
 		case tar.TypeReg:
+			if err := budget.add(header.Name, uint64(max(header.Size, 0))); err != nil {
+				return err
+			}
+
 			if err := target.MkParentDir(); err != nil {
 				return err
 			}
 
-			if err := p.extractTarFile(target, header, tarReader, options.MaxSize); err != nil {
+			guard.startEntry()
+
+			if err := p.extractTarFile(target, header, tarReader, options.MaxSize, guard); err != nil {
 				return err
 			}
 		}
@@ -233,8 +602,8 @@ func (p *FsPath) Untar(destDir string, opts ...CompressOption) error {
 func (p *FsPath) Unzip(destDir string, opts ...CompressOption) error {
 	options := applyCompressOptions(opts...)
 
-	subDir := Path(destDir).Join(strings.TrimSuffix(p.Name, ".zip"))
-	if err := subDir.MkdirAll(_mode755); err != nil {
+	subDir := Path(destDir).Join(archiveStem(p.Name))
+	if err := subDir.MkdirAll(DefaultDirMode); err != nil {
 		return fmt.Errorf("failed to create subdirectory: %w", err)
 	}
 
@@ -244,8 +613,22 @@ func (p *FsPath) Unzip(destDir string, opts ...CompressOption) error {
 	}
 	defer reader.Close()
 
+	if options.CheckDiskSpace {
+		if err := ensureDiskSpace(subDir, reader.File); err != nil {
+			return err
+		}
+	}
+
+	budget := newExtractionBudget(options)
+
 	for _, file := range reader.File {
-		err := p.extractZipFile(file, subDir, options.MaxSize)
+		if !file.FileInfo().IsDir() {
+			if err := budget.add(file.Name, file.UncompressedSize64); err != nil {
+				return err
+			}
+		}
+
+		err := p.extractZipFile(file, subDir, options)
 		if err != nil {
 			return err
 		}
@@ -254,7 +637,62 @@ func (p *FsPath) Unzip(destDir string, opts ...CompressOption) error {
 	return nil
 }
 
-func (p *FsPath) extractTarFile(target *FsPath, header *tar.Header, tarReader *tar.Reader, maxSize int64) error {
+// extractionBudget tracks cumulative bytes and files across one extraction,
+// guarding against decompression bombs made of many moderate entries.
+type extractionBudget struct {
+	maxTotalSize uint64
+	maxFiles     int
+	totalSize    uint64
+	files        int
+}
+
+func newExtractionBudget(options CompressOptions) *extractionBudget {
+	return &extractionBudget{
+		maxTotalSize: uint64(max(options.MaxTotalSize, 0)),
+		maxFiles:     options.MaxFiles,
+	}
+}
+
+// add accounts for one file of the given declared size before it is extracted.
+func (b *extractionBudget) add(name string, size uint64) error {
+	b.files++
+	if b.maxFiles > 0 && b.files > b.maxFiles {
+		return fmt.Errorf("%w: %s is file %d, max allowed: %d", ErrTooManyFiles, name, b.files, b.maxFiles)
+	}
+
+	b.totalSize += size
+	if b.maxTotalSize > 0 && (b.totalSize > b.maxTotalSize || b.totalSize < size) {
+		return fmt.Errorf("%w: reached %d bytes at %s, max allowed: %d bytes", ErrArchiveTooLarge, b.totalSize, name, b.maxTotalSize)
+	}
+
+	return nil
+}
+
+// ensureDiskSpace returns ErrInsufficientSpace if the uncompressed files don't fit at dest.
+// The check is skipped on platforms where DiskUsage is not supported.
+func ensureDiskSpace(dest *FsPath, files []*zip.File) error {
+	_, _, available, err := dest.DiskUsage()
+	if errors.Is(err, ErrNotSupported) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check disk space: %w", err)
+	}
+
+	var needed uint64
+	for _, file := range files {
+		needed += file.UncompressedSize64
+	}
+
+	if needed > available {
+		return fmt.Errorf("%w: need %d bytes, %d available at %s", ErrInsufficientSpace, needed, available, dest.absPath)
+	}
+
+	return nil
+}
+
+func (p *FsPath) extractTarFile(target *FsPath, header *tar.Header, tarReader *tar.Reader, maxSize int64, guard *ratioGuard) error {
 	if header.Size > maxSize {
 		return fmt.Errorf("%w: %s (size: %d bytes, max allowed: %d bytes)", ErrFileTooLarge, header.Name, header.Size, maxSize)
 	}
@@ -265,9 +703,11 @@ func (p *FsPath) extractTarFile(target *FsPath, header *tar.Header, tarReader *t
 	}
 	defer file.Close()
 
-	written, err := io.Copy(file, io.LimitReader(tarReader, header.Size))
+	audit(AuditWrite, target.absPath)
+
+	written, err := io.Copy(io.MultiWriter(guard, file), io.LimitReader(tarReader, header.Size))
 	if err != nil {
-		return err
+		return fmt.Errorf("%s: %w", header.Name, err)
 	}
 
 	if written != header.Size {
@@ -277,16 +717,22 @@ func (p *FsPath) extractTarFile(target *FsPath, header *tar.Header, tarReader *t
 	return nil
 }
 
-func (p *FsPath) prepareUntarEnvironment() (*tar.Reader, func(), error) {
+func (p *FsPath) prepareUntarEnvironment(gzipped bool) (*tar.Reader, *countingReader, func(), error) {
 	file, err := p.fs.Open(p.absPath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	compressed := &countingReader{reader: file}
+
+	if !gzipped {
+		return tar.NewReader(compressed), compressed, func() { file.Close() }, nil
 	}
 
-	gzr, err := gzip.NewReader(file)
+	gzr, err := gzip.NewReader(compressed)
 	if err != nil {
 		file.Close()
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	tarReader := tar.NewReader(gzr)
@@ -296,26 +742,84 @@ func (p *FsPath) prepareUntarEnvironment() (*tar.Reader, func(), error) {
 		file.Close()
 	}
 
-	return tarReader, cleanup, nil
+	return tarReader, compressed, cleanup, nil
 }
 
-func (p *FsPath) extractZipFile(file *zip.File, destDir *FsPath, maxSize int64) error {
-	// Use FsPath.Join instead of filepath.Join
-	filePath := destDir.Join(file.Name)
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.count += int64(n)
+
+	return n, err
+}
 
-	// Check for path traversal
-	if !strings.HasPrefix(filePath.absPath, destDir.absPath) {
-		return fmt.Errorf("%w: illegal file path %s", ErrIllegalFilePath, file.Name)
+// ratioGuard is an io.Writer that fails once the bytes written through it for the current
+// entry exceed maxRatio times the compressed bytes consumed since the entry started. It is used
+// for streamed formats like tar.gz, where per-entry compressed sizes are unknown.
+//
+// gzip reads the compressed stream ahead in gzipReadAhead chunks, so the start of an entry may
+// already have been counted for the previous one. That slack is added to the entry's count, so
+// the guard can let a bomb run slightly past maxRatio but never rejects a legitimate entry.
+type ratioGuard struct {
+	compressed *countingReader
+	maxRatio   float64
+	start      int64
+	written    int64
+}
+
+// startEntry resets the guard for the next entry.
+func (g *ratioGuard) startEntry() {
+	g.start = g.compressed.count
+	g.written = 0
+}
+
+func (g *ratioGuard) Write(b []byte) (int, error) {
+	g.written += int64(len(b))
+	compressed := g.compressed.count - g.start + gzipReadAhead
+
+	if exceedsRatio(uint64(g.written), uint64(compressed), g.maxRatio) {
+		return 0, fmt.Errorf("%w: %d bytes from %d compressed (max ratio: %.0f)", ErrRatioTooHigh, g.written, compressed, g.maxRatio)
+	}
+
+	return len(b), nil
+}
+
+// exceedsRatio reports whether uncompressed/compressed is above maxRatio, ignoring sizes below
+// ratioCheckMinSize, an unknown (zero) compressed size and a disabled (non-positive) maxRatio.
+func exceedsRatio(uncompressed, compressed uint64, maxRatio float64) bool {
+	if maxRatio <= 0 || compressed == 0 || uncompressed < ratioCheckMinSize {
+		return false
+	}
+
+	return float64(uncompressed)/float64(compressed) > maxRatio
+}
+
+func (p *FsPath) extractZipFile(file *zip.File, destDir *FsPath, options CompressOptions) error {
+	maxSize := options.MaxSize
+
+	filePath, err := destDir.SafeJoin(file.Name)
+	if err != nil {
+		return err
 	}
 
 	if file.FileInfo().IsDir() {
-		return filePath.MkdirAll(_mode755)
+		return filePath.MkdirAll(DefaultDirMode)
 	}
 
 	if file.UncompressedSize64 > uint64(maxSize) {
 		return fmt.Errorf("%w: %s (size: %d bytes, max allowed: %d bytes)", ErrFileTooLarge, file.Name, file.UncompressedSize64, maxSize)
 	}
 
+	if exceedsRatio(file.UncompressedSize64, file.CompressedSize64, options.MaxRatio) {
+		return fmt.Errorf("%w: %s (%d bytes from %d compressed, max ratio: %.0f)",
+			ErrRatioTooHigh, file.Name, file.UncompressedSize64, file.CompressedSize64, options.MaxRatio)
+	}
+
 	srcFile, err := file.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open file in zip: %w", err)
@@ -332,6 +836,8 @@ func (p *FsPath) extractZipFile(file *zip.File, destDir *FsPath, maxSize int64)
 	}
 	defer destFile.Close()
 
+	audit(AuditWrite, filePath.absPath)
+
 	written, err := io.Copy(destFile, io.LimitReader(srcFile, maxSize))
 	if err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
@@ -350,7 +856,7 @@ func (p *FsPath) prepareCompression(fileName, extension string) (*FsPath, afero.
 	}
 
 	// Ensure the fileName has the correct extension
-	if !strings.HasSuffix(fileName, extension) {
+	if !strings.HasSuffix(strings.ToLower(fileName), extension) {
 		fileName += extension
 	}
 
@@ -363,6 +869,8 @@ func (p *FsPath) prepareCompression(fileName, extension string) (*FsPath, afero.
 		return nil, nil, fmt.Errorf("failed to create %s file: %w", extension, err)
 	}
 
+	audit(AuditWrite, compressedPath.absPath)
+
 	return compressedPath, file, nil
 }
 
@@ -383,20 +891,8 @@ func (p *FsPath) compressDirectoryToWriter(createWriter writerFactory) (int, err
 			return nil
 		}
 
-		file, err := p.fs.Open(p.Join(relPath).absPath)
-		if err != nil {
-			return fmt.Errorf("failed to open file: %w", err)
-		}
-		defer file.Close()
-
-		writer, err := createWriter(relPath, info)
-		if err != nil {
-			return fmt.Errorf("failed to create writer for %s: %w", relPath, err)
-		}
-
-		_, err = io.Copy(writer, file)
-		if err != nil {
-			return fmt.Errorf("failed to write file %s: %w", relPath, err)
+		if err := p.Join(relPath).copyToArchive(relPath, createWriter); err != nil {
+			return err
 		}
 
 		totalFiles++
@@ -409,3 +905,28 @@ func (p *FsPath) compressDirectoryToWriter(createWriter writerFactory) (int, err
 
 	return totalFiles, nil
 }
+
+// copyToArchive streams the file into the archive entry created by createWriter.
+func (p *FsPath) copyToArchive(name string, createWriter writerFactory) error {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file %s: %w", p.absPath, err)
+	}
+
+	writer, err := createWriter(name, info)
+	if err != nil {
+		return fmt.Errorf("failed to create writer for %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", name, err)
+	}
+
+	return nil
+}
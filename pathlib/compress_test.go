@@ -1,6 +1,10 @@
 package pathlib
 
 import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"strings"
 	"testing"
@@ -182,3 +186,312 @@ func (s *CompressSuite) verifyCompressedContent(compressedPath *FsPath, expected
 		s.T().Logf("Expected files: %v", expectedFiles)
 	}
 }
+
+func (s *CompressSuite) TestZipFiles() {
+	root := Path(s.tempDir)
+	files := []*FsPath{
+		root.Join("a", "report.txt"),
+		root.Join("b", "notes.md"),
+		root.Join("c", "deep", "report.txt"),
+	}
+
+	for i, file := range files {
+		s.Require().NoError(file.MkParentDir())
+		s.Require().NoError(file.WriteText(fmt.Sprintf("content %d", i)))
+	}
+
+	s.Run("Rename collisions and extract", func() {
+		dest := root.Join("bundle.zip")
+
+		count, err := ZipFiles(dest.absPath, files)
+		s.Require().NoError(err)
+		s.Equal(3, count)
+
+		extractDir := Path(s.T().TempDir())
+		s.Require().NoError(dest.Unzip(extractDir.absPath))
+
+		extracted := extractDir.Join("bundle")
+		s.Equal("content 0", extracted.Join("report.txt").MustReadText())
+		s.Equal("content 1", extracted.Join("notes.md").MustReadText())
+		s.Equal("content 2", extracted.Join("report (1).txt").MustReadText())
+	})
+
+	s.Run("Skip collisions", func() {
+		count, err := ZipFiles(root.Join("skip.zip").absPath, files, WithCollisionStrategy(CollisionSkip))
+		s.Require().NoError(err)
+		s.Equal(2, count)
+	})
+
+	s.Run("Error on collision", func() {
+		dest := root.Join("error.zip")
+		count, err := ZipFiles(dest.absPath, files, WithCollisionStrategy(CollisionError))
+		s.Require().ErrorIs(err, ErrDuplicateEntry)
+		s.Zero(count)
+		s.False(dest.Exists(), "the partial archive is removed")
+	})
+
+	s.Run("Missing file", func() {
+		dest := root.Join("missing.zip")
+		_, err := ZipFiles(dest.absPath, append([]*FsPath{files[0]}, root.Join("missing.txt")))
+		s.Require().Error(err)
+		s.False(dest.Exists(), "the partial archive is removed")
+	})
+
+	s.Run("Unknown collision strategy", func() {
+		dest := root.Join("unknown.zip")
+		_, err := ZipFiles(dest.absPath, files, WithCollisionStrategy(CollisionStrategy(42)))
+		s.Require().ErrorIs(err, ErrUnknownCollision)
+		s.False(dest.Exists())
+	})
+
+	s.Run("Custom entry names", func() {
+		dest := root.Join("named.zip")
+		count, err := ZipFiles(dest.absPath, files, WithEntryName(func(file *FsPath) string {
+			name, _ := file.RelativeTo(root.absPath)
+			return name
+		}))
+		s.Require().NoError(err)
+		s.Equal(3, count)
+
+		extractDir := Path(s.T().TempDir())
+		s.Require().NoError(dest.Unzip(extractDir.absPath))
+		s.Equal("content 2", extractDir.Join("named", "c", "deep", "report.txt").MustReadText())
+	})
+}
+
+func (s *CompressSuite) TestCompressReaderToZip() {
+	dest := Path(s.tempDir).Join("memory.zip")
+
+	err := CompressReaderToZip(map[string]io.Reader{
+		"hello.txt":       strings.NewReader("hello world"),
+		"nested/data.csv": strings.NewReader("a,b\n1,2\n"),
+	}, dest.absPath)
+	s.Require().NoError(err)
+
+	reader, err := zip.OpenReader(dest.absPath)
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	contents := make(map[string]string)
+
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		s.Require().NoError(err)
+
+		data, err := io.ReadAll(rc)
+		s.Require().NoError(err)
+		rc.Close()
+
+		contents[file.Name] = string(data)
+	}
+
+	s.Equal(map[string]string{
+		"hello.txt":       "hello world",
+		"nested/data.csv": "a,b\n1,2\n",
+	}, contents)
+}
+
+func (s *CompressSuite) TestUnzipRejectsTraversal() {
+	archive := Path(s.tempDir).Join("slip.zip")
+	s.Require().NoError(CompressReaderToZip(map[string]io.Reader{
+		"../escaped.txt": strings.NewReader("gotcha"),
+	}, archive.absPath))
+
+	extractDir := Path(s.T().TempDir())
+	err := archive.Unzip(extractDir.absPath)
+	s.Require().ErrorIs(err, ErrIllegalFilePath)
+	s.False(extractDir.Join("escaped.txt").Exists())
+}
+
+func (s *CompressSuite) TestExtractionTotalLimits() {
+	srcDir := Path(s.tempDir).Join("bomb")
+	entries := make(map[string]io.Reader)
+
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("part%d.bin", i)
+		content := strings.Repeat("x", 100)
+
+		entries[name] = strings.NewReader(content)
+		s.Require().NoError(srcDir.Join(name).MkParentDir())
+		s.Require().NoError(srcDir.Join(name).WriteText(content))
+	}
+
+	zipPath := Path(s.tempDir).Join("bomb.zip")
+	s.Require().NoError(CompressReaderToZip(entries, zipPath.absPath))
+
+	tarPath, _, err := srcDir.TarGzDir("bomb.tar.gz")
+	s.Require().NoError(err)
+
+	for _, archive := range []*FsPath{zipPath, tarPath} {
+		extract := archive.Unzip
+		if strings.HasSuffix(archive.Name, ".tar.gz") {
+			extract = archive.Untar
+		}
+
+		s.Run(archive.Name, func() {
+			// every file is within MaxSize, but together they exceed MaxTotalSize
+			err := extract(s.T().TempDir(), WithMaxSize(200), WithMaxTotalSize(450))
+			s.Require().ErrorIs(err, ErrArchiveTooLarge)
+
+			err = extract(s.T().TempDir(), WithMaxFiles(4))
+			s.Require().ErrorIs(err, ErrTooManyFiles)
+
+			err = extract(s.T().TempDir(), WithMaxSize(200), WithMaxTotalSize(500), WithMaxFiles(5))
+			s.Require().NoError(err)
+		})
+	}
+}
+
+func (s *CompressSuite) TestExtractionRatioGuard() {
+	bomb := strings.Repeat("\x00", 10*1024*1024)
+
+	srcDir := Path(s.tempDir).Join("zeros")
+	s.Require().NoError(srcDir.MkdirAll(0o755))
+	s.Require().NoError(srcDir.Join("zeros.bin").WriteText(bomb))
+
+	zipPath := Path(s.tempDir).Join("zeros.zip")
+	s.Require().NoError(CompressReaderToZip(map[string]io.Reader{
+		"zeros.bin": strings.NewReader(bomb),
+	}, zipPath.absPath))
+
+	tarPath, _, err := srcDir.TarGzDir("zeros.tar.gz")
+	s.Require().NoError(err)
+
+	for _, archive := range []*FsPath{zipPath, tarPath} {
+		extract := archive.Unzip
+		if strings.HasSuffix(archive.Name, ".tar.gz") {
+			extract = archive.Untar
+		}
+
+		s.Run(archive.Name, func() {
+			err := extract(s.T().TempDir(), WithMaxRatio(500))
+			s.Require().ErrorIs(err, ErrRatioTooHigh)
+
+			s.Require().NoError(extract(s.T().TempDir(), WithMaxRatio(0)), "WithMaxRatio(0) disables the guard")
+		})
+	}
+}
+
+func (s *CompressSuite) TestExtractionRatioGuardDefault() {
+	srcDir := Path(s.tempDir).Join("logs")
+	s.Require().NoError(srcDir.MkdirAll(0o755))
+
+	var app strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&app, "2024-05-01T12:00:00Z INFO request handled path=/api/items status=200 id=%d\n", i%50)
+	}
+
+	// a few KB of compressed heartbeats that gzip reads ahead together with the previous entry
+	heartbeat := strings.Repeat("2024-05-01T12:00:00Z INFO heartbeat ok\n", 20000)
+	s.Require().NoError(srcDir.Join("a-app.log").WriteText(app.String()))
+	s.Require().NoError(srcDir.Join("b-heartbeat.log").WriteText(heartbeat))
+
+	zipPath, _, err := srcDir.ZipDir("logs.zip")
+	s.Require().NoError(err)
+
+	tarPath, _, err := srcDir.TarGzDir("logs.tar.gz")
+	s.Require().NoError(err)
+
+	for _, archive := range []*FsPath{zipPath, tarPath} {
+		extract := archive.Unzip
+		if strings.HasSuffix(archive.Name, ".tar.gz") {
+			extract = archive.Untar
+		}
+
+		s.Run(archive.Name, func() {
+			extractDir := Path(s.T().TempDir())
+			s.Require().NoError(extract(extractDir.absPath), "legitimate logs pass the default guard")
+			s.Equal(heartbeat, extractDir.Join("logs", "b-heartbeat.log").MustReadText())
+		})
+	}
+}
+
+func (s *CompressSuite) TestUntarRatioGuardPerEntry() {
+	srcDir := Path(s.tempDir).Join("mixed")
+	s.Require().NoError(srcDir.MkdirAll(0o755))
+
+	// an incompressible entry first keeps the cumulative ratio of the archive low
+	noise := make([]byte, 4*1024*1024)
+	_, err := rand.New(rand.NewSource(1)).Read(noise)
+	s.Require().NoError(err)
+	s.Require().NoError(srcDir.Join("a-noise.bin").WriteBytes(noise))
+	s.Require().NoError(srcDir.Join("b-zeros.bin").WriteText(strings.Repeat("\x00", 10*1024*1024)))
+
+	tarPath, _, err := srcDir.TarGzDir("mixed.tar.gz")
+	s.Require().NoError(err)
+
+	err = tarPath.Untar(s.T().TempDir(), WithMaxRatio(100))
+	s.Require().ErrorIs(err, ErrRatioTooHigh)
+	s.Contains(err.Error(), "b-zeros.bin")
+}
+
+func (s *CompressSuite) TestExtract() {
+	dirPath := Path(s.tempDir).Join("data")
+	s.Require().NoError(dirPath.MkdirAll(0o755))
+	testFiles := s.createTestFiles(dirPath)
+
+	zipPath, _, err := dirPath.ZipDir("data.zip")
+	s.Require().NoError(err)
+
+	tarGzPath, _, err := dirPath.TarGzDir("data.tar.gz")
+	s.Require().NoError(err)
+
+	tgzPath := Path(s.tempDir).Join("DATA.TGZ")
+	s.Require().NoError(tarGzPath.Copy(tgzPath.absPath))
+
+	for _, archive := range []*FsPath{zipPath, tarGzPath, tgzPath} {
+		s.Run(archive.Name, func() {
+			extractDir := Path(s.T().TempDir())
+			s.Require().NoError(archive.Extract(extractDir.absPath))
+
+			contentDir := extractDir.Join(archiveStem(archive.Name))
+			for _, tf := range testFiles {
+				s.Equal(tf.content, contentDir.Join(tf.name).MustReadText())
+			}
+		})
+	}
+
+	s.Equal("DATA", archiveStem(tgzPath.Name))
+
+	err = dirPath.Join("file1.txt").Extract(s.T().TempDir())
+	s.Require().ErrorIs(err, ErrUnsupportedArchive)
+}
+
+func (s *CompressSuite) TestCompressBySuffix() {
+	dirPath := Path(s.tempDir).Join("foo")
+	s.Require().NoError(dirPath.MkdirAll(0o755))
+	testFiles := s.createTestFiles(dirPath)
+
+	magic := map[string][]byte{
+		"foo.tgz":    {0x1f, 0x8b},
+		"foo.tar.gz": {0x1f, 0x8b},
+		"foo.zip":    []byte("PK\x03\x04"),
+		"foo.TAR":    nil,
+	}
+
+	for name, header := range magic {
+		s.Run(name, func() {
+			archive, count, err := dirPath.Compress(name)
+			s.Require().NoError(err)
+			s.Equal(name, archive.Name)
+			s.Equal(len(testFiles), count)
+
+			data := archive.MustReadBytes()
+			if header != nil {
+				s.Equal(header, data[:len(header)])
+			} else {
+				s.Equal("ustar", string(data[257:262]))
+			}
+
+			extractDir := Path(s.T().TempDir())
+			s.Require().NoError(archive.Extract(extractDir.absPath))
+
+			for _, tf := range testFiles {
+				s.Equal(tf.content, extractDir.Join("foo", tf.name).MustReadText())
+			}
+		})
+	}
+
+	_, _, err := dirPath.Compress("foo.rar")
+	s.Require().ErrorIs(err, ErrUnsupportedArchive)
+}
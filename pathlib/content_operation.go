@@ -1,15 +1,23 @@
 package pathlib
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/afero"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
 )
 
 const (
@@ -17,6 +25,11 @@ const (
 	SepRuneTsv = '\t'
 )
 
+var (
+	ErrInvalidRange = errors.New("invalid byte range")
+	ErrNotSeekable  = errors.New("file is not seekable")
+)
+
 func (p *FsPath) MustGetBytes() []byte {
 	b, err := p.GetBytes()
 	p.e(err)
@@ -25,7 +38,9 @@ func (p *FsPath) MustGetBytes() []byte {
 }
 
 func (p *FsPath) GetBytes() ([]byte, error) {
-	return afero.ReadFile(p.fs, p.absPath)
+	data, err := afero.ReadFile(p.fs, p.absPath)
+
+	return data, auditResult(AuditRead, p.absPath, err)
 }
 
 func (p *FsPath) SetBytes(data []byte) error {
@@ -33,7 +48,7 @@ func (p *FsPath) SetBytes(data []byte) error {
 		return err
 	}
 
-	return afero.WriteFile(p.fs, p.absPath, data, FileMode644)
+	return auditResult(AuditWrite, p.absPath, afero.WriteFile(p.fs, p.absPath, data, FileMode644))
 }
 
 // MustSetString sets the file content as a string, panics on error
@@ -62,6 +77,86 @@ func (p *FsPath) GetString() (string, error) {
 	return string(data), nil
 }
 
+// GetStringNoBOM reads the file like GetString, but strips a leading byte order mark.
+//
+// A UTF-8 BOM (EF BB BF) is removed. A UTF-16 BOM (FF FE or FE FF) is removed and the
+// rest of the file is decoded from UTF-16 to UTF-8. Files without a BOM are returned unchanged.
+//
+// Returns:
+//   - string: The file content without BOM, as UTF-8.
+//   - error: An error if the file cannot be read or the UTF-16 content cannot be decoded.
+//
+// Example usage:
+//
+//	// CSV exported from Excel, starting with EF BB BF
+//	content, err := Path("export.csv").GetStringNoBOM()
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Println(strings.HasPrefix(content, "name,")) // true
+func (p *FsPath) GetStringNoBOM() (string, error) {
+	data, err := p.GetBytes()
+	if err != nil {
+		return "", err
+	}
+
+	decoded, _, err := transform.Bytes(unicode.BOMOverride(encoding.Nop.NewDecoder()), data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", p.absPath, err)
+	}
+
+	return string(decoded), nil
+}
+
+// WriteEncoded encodes the UTF-8 string data into enc and writes the result to the file,
+// creating or overwriting it like SetBytes.
+//
+// Parameters:
+//   - data: The UTF-8 text to write.
+//   - enc: The target encoding, e.g. simplifiedchinese.GBK or charmap.Windows1252.
+//
+// Returns:
+//   - error: An error if data contains characters enc cannot represent, or if writing fails.
+//
+// Example usage:
+//
+//	err := Path("report.txt").WriteEncoded("你好", simplifiedchinese.GBK)
+func (p *FsPath) WriteEncoded(data string, enc encoding.Encoding) error {
+	encoded, _, err := transform.Bytes(enc.NewEncoder(), []byte(data))
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", p.absPath, err)
+	}
+
+	return p.SetBytes(encoded)
+}
+
+// ReadDecoded reads the file and decodes its content from enc into a UTF-8 string.
+// It is the reverse of WriteEncoded.
+//
+// Parameters:
+//   - enc: The encoding the file is stored in.
+//
+// Returns:
+//   - string: The decoded UTF-8 content.
+//   - error: An error if the file cannot be read or decoded.
+//
+// Example usage:
+//
+//	text, err := Path("legacy.txt").ReadDecoded(simplifiedchinese.GBK)
+func (p *FsPath) ReadDecoded(enc encoding.Encoding) (string, error) {
+	data, err := p.GetBytes()
+	if err != nil {
+		return "", err
+	}
+
+	decoded, _, err := transform.Bytes(enc.NewDecoder(), data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", p.absPath, err)
+	}
+
+	return string(decoded), nil
+}
+
 // GetLines reads the file and returns its contents as a slice of strings.
 //
 // Each string in the returned slice represents a line in the file.
@@ -188,9 +283,312 @@ func (p *FsPath) MustGetJSON(v interface{}) {
 	p.e(p.GetJSON(v))
 }
 
+// GetJSONOnto reads the file and unmarshals its JSON content onto v, which may already hold values.
+//
+// Only the keys present in the file are assigned: fields of v without a matching key keep their
+// current values, so v can be pre-populated with defaults. Note that JSON arrays replace slices
+// as a whole, while JSON objects are merged into existing maps and structs.
+//
+// Parameters:
+//   - v: A pointer to the pre-populated value to overlay the file content onto.
+//
+// Returns:
+//   - error: An error if the file cannot be read or its content cannot be unmarshaled.
+//
+// Example usage:
+//
+//	config := Config{Port: 8080, LogLevel: "info"}
+//	if err := path.GetJSONOnto(&config); err != nil {
+//	    // handle error
+//	}
+//	// config.Port is still 8080 if the file doesn't set "port"
+func (p *FsPath) GetJSONOnto(v interface{}) error {
+	return p.GetJSON(v)
+}
+
+// LoadConfig initializes v from defaults and then overlays the JSON content of the file onto it
+// with GetJSONOnto, implementing the defaults-then-override pattern in one call.
+//
+// Defaults are copied into v by a JSON round trip, so only exported, JSON-visible fields are
+// copied, and v doesn't share slices or maps with defaults.
+//
+// Parameters:
+//   - v: A pointer to the value to load into.
+//   - defaults: The default values, either a value or a pointer of a type compatible with v.
+//
+// Returns:
+//   - error: An error if the defaults cannot be copied or the file cannot be read or unmarshaled.
+//
+// Example usage:
+//
+//	var config Config
+//	err := Path("/etc/app/config.json").LoadConfig(&config, Config{Port: 8080, LogLevel: "info"})
+func (p *FsPath) LoadConfig(v interface{}, defaults interface{}) error {
+	data, err := json.Marshal(defaults)
+	if err != nil {
+		return fmt.Errorf("failed to marshal defaults: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to apply defaults: %w", err)
+	}
+
+	return p.GetJSONOnto(v)
+}
+
+// WriteJSONAtomic marshals v as indented JSON and atomically replaces the file with it.
+//
+// The data is written to a temporary file in the same directory, synced to disk and then
+// renamed over the destination, so a crash mid-write never leaves a truncated file behind.
+// The mode of an existing file is preserved.
+//
+// Parameters:
+//   - v: The value to marshal.
+//
+// Returns:
+//   - error: An error if marshaling, writing or renaming fails. In that case the existing
+//     file is left untouched and no temporary file remains. An error wrapping ErrDirNotSynced
+//     is different: the file was already replaced and only the directory sync failed.
+//
+// Example usage:
+//
+//	state := map[string]int{"offset": 42}
+//	if err := path.WriteJSONAtomic(state); err != nil {
+//	    // handle error
+//	}
+func (p *FsPath) WriteJSONAtomic(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return p.writeFileAtomic(data)
+}
+
 // Reader returns an io.Reader for the file
 func (p *FsPath) Reader() (io.Reader, error) {
-	return p.fs.Open(p.absPath)
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(AuditRead, p.absPath)
+
+	return file, nil
+}
+
+// ReadSeeker opens the file for random access, e.g. to serve it with http.ServeContent.
+//
+// Returns:
+//   - io.ReadSeekCloser: The open file. The caller is responsible for closing it.
+//   - int64: The file size at the time it was opened.
+//   - error: An error wrapping ErrNotSeekable if the backing file system does not support
+//     seeking, or any error raised while opening or stat'ing the file.
+//
+// Example usage:
+//
+//	file, size, err := Path("video.mp4").ReadSeeker()
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusNotFound)
+//	    return
+//	}
+//	defer file.Close()
+//	log.Printf("serving %d bytes", size)
+//	http.ServeContent(w, r, "video.mp4", time.Time{}, file)
+func (p *FsPath) ReadSeeker() (io.ReadSeekCloser, int64, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	if _, err := file.Seek(0, io.SeekCurrent); err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("%w: %s: %w", ErrNotSeekable, p.absPath, err)
+	}
+
+	audit(AuditRead, p.absPath)
+
+	return file, info.Size(), nil
+}
+
+// WriteFromReader writes everything read from r to the file, creating the parent
+// directory if needed and creating or truncating the file.
+//
+// Parameters:
+//   - r: The source stream, e.g. an HTTP response body. It is read until EOF but not closed.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: An error if the parent directory or file cannot be created, or if copying fails.
+//     A partially written file is left in place on copy errors.
+//
+// Example usage:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//	    return err
+//	}
+//	defer resp.Body.Close()
+//
+//	n, err := Path("/tmp/downloads/file.zip").WriteFromReader(resp.Body)
+func (p *FsPath) WriteFromReader(r io.Reader) (int64, error) {
+	if err := p.MkParentDir(); err != nil {
+		return 0, err
+	}
+
+	file, err := p.fs.OpenFile(p.absPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, FileMode644)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(file, r)
+	if err != nil {
+		file.Close()
+		return written, err
+	}
+
+	return written, auditResult(AuditWrite, p.absPath, file.Close())
+}
+
+// WriteFromReaderAtomic is the durable version of WriteFromReader: r is streamed into a
+// temporary file in the same directory, synced to disk and renamed over the destination,
+// so the file only ever appears complete, e.g. for large downloads.
+//
+// If reading r or writing fails, the temporary file is removed and the destination is left
+// untouched (or absent). The mode of an existing file is preserved.
+//
+// Parameters:
+//   - r: The source stream, e.g. an HTTP response body. It is read until EOF but not closed.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: An error if the parent directory or the temporary file cannot be created,
+//     or if copying, syncing or renaming fails. An error wrapping ErrDirNotSynced means
+//     the file was already replaced and only the directory sync failed.
+//
+// Example usage:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//	    return err
+//	}
+//	defer resp.Body.Close()
+//
+//	n, err := Path("/data/downloads/dataset.tar.gz").WriteFromReaderAtomic(resp.Body)
+func (p *FsPath) WriteFromReaderAtomic(r io.Reader) (int64, error) {
+	return p.writeAtomicFrom(r)
+}
+
+// WriteAt writes data at the given byte offset of the file without truncating it, e.g. to patch
+// a header in place. The file (and its parent directory) is created if it doesn't exist.
+//
+// Bytes outside [offset, offset+len(data)) are left intact. Writing past the end of the file
+// extends it; on most file systems the gap is filled with zero bytes.
+//
+// Parameters:
+//   - data: The bytes to write.
+//   - offset: The 0-based position of the first byte to write.
+//
+// Returns:
+//   - int: The number of bytes written.
+//   - error: An error wrapping ErrInvalidRange if offset is negative, or an error if the file
+//     cannot be opened or written.
+//
+// Example usage:
+//
+//	// set the version byte of a binary header
+//	_, err := Path("/data/archive.bin").WriteAt([]byte{2}, 4)
+func (p *FsPath) WriteAt(data []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidRange, offset)
+	}
+
+	if err := p.MkParentDir(); err != nil {
+		return 0, err
+	}
+
+	file, err := p.fs.OpenFile(p.absPath, os.O_RDWR|os.O_CREATE, FileMode644)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := file.WriteAt(data, offset)
+	if err != nil {
+		file.Close()
+		return written, err
+	}
+
+	return written, auditResult(AuditWrite, p.absPath, file.Close())
+}
+
+// TeeReader returns a reader that copies everything read from src into the file at this path,
+// so a stream can be consumed and persisted in a single pass.
+//
+// The file is created (or truncated) when TeeReader is called, and its parent directory
+// is created if missing. Bytes reach the file only as they are read from the returned reader.
+//
+// Parameters:
+//   - src: The source stream.
+//
+// Returns:
+//   - io.ReadCloser: Reads from src. Close flushes and closes the file; it does not close src.
+//   - error: An error if the parent directory or the file cannot be created.
+//
+// Example usage:
+//
+//	resp, _ := http.Get(url)
+//	defer resp.Body.Close()
+//
+//	tee, err := Path("/tmp/download.bin").TeeReader(resp.Body)
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer tee.Close()
+//
+//	hash := sha256.New()
+//	_, err = io.Copy(hash, tee)
+func (p *FsPath) TeeReader(src io.Reader) (io.ReadCloser, error) {
+	if err := p.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	file, err := p.fs.Create(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(AuditWrite, p.absPath)
+
+	writer := bufio.NewWriter(file)
+
+	return &teeReadCloser{
+		Reader: io.TeeReader(src, writer),
+		writer: writer,
+		file:   file,
+	}, nil
+}
+
+type teeReadCloser struct {
+	io.Reader
+	writer *bufio.Writer
+	file   afero.File
+}
+
+func (t *teeReadCloser) Close() error {
+	flushErr := t.writer.Flush()
+	closeErr := t.file.Close()
+
+	if flushErr != nil {
+		return flushErr
+	}
+
+	return closeErr
 }
 
 // CSVGetSlices reads the CSV file and returns its content as slices.
@@ -291,6 +689,12 @@ func (p *FsPath) ReadText() (string, error) {
 	return p.GetString()
 }
 
+// ReadTextNoBOM reads the contents of the file as a string, stripping a leading BOM.
+// See GetStringNoBOM.
+func (p *FsPath) ReadTextNoBOM() (string, error) {
+	return p.GetStringNoBOM()
+}
+
 // ReadBytes reads the contents of the file and returns it as a byte slice.
 func (p *FsPath) ReadBytes() ([]byte, error) {
 	return p.GetBytes()
@@ -346,13 +750,42 @@ func (p *FsPath) MustAppendBytes(data []byte) {
 	p.e(p.AppendBytes(data))
 }
 
-// appendData is a helper function to append data to a file
-func (p *FsPath) appendData(data []byte) error {
+// Appender opens the file once for appending and returns a writer for streaming appends,
+// creating the file and its parent directories if they don't exist.
+//
+// Unlike AppendText and AppendBytes, which open and close the file on every call,
+// the returned writer keeps the file open until it is closed, which makes it suitable
+// for appending many times in a loop. The caller must close the writer.
+//
+// Example:
+//
+//	w, err := Path("/var/log/app.log").Appender()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer w.Close()
+//
+//	for _, line := range lines {
+//	    fmt.Fprintln(w, line)
+//	}
+func (p *FsPath) Appender() (io.WriteCloser, error) {
 	if err := p.MkParentDir(); err != nil {
-		return err
+		return nil, err
 	}
 
 	file, err := p.fs.OpenFile(p.absPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FileMode644)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(AuditWrite, p.absPath)
+
+	return file, nil
+}
+
+// appendData is a helper function to append data to a file
+func (p *FsPath) appendData(data []byte) error {
+	file, err := p.Appender()
 	if err != nil {
 		return err
 	}
@@ -378,3 +811,343 @@ func (p *FsPath) GetMD5() (string, error) {
 
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
+
+// ContentEquals reports whether this file and other have byte-for-byte identical content.
+//
+// Sizes are compared first, so files of different sizes return false without being read.
+// Otherwise both files are streamed through buffered readers and compared chunk by chunk,
+// stopping at the first difference; neither file is loaded fully into memory.
+// A path compared with itself (or with a hard link to the same file) returns true without reading.
+//
+// Parameters:
+//   - other: The file to compare with.
+//
+// Returns:
+//   - bool: true if both files have the same content.
+//   - error: An error if either file cannot be stat'ed, opened or read.
+//
+// Example usage:
+//
+//	same, err := Path("build/app.bin").ContentEquals(Path("release/app.bin"))
+//	if err != nil {
+//	    // handle error
+//	}
+func (p *FsPath) ContentEquals(other *FsPath) (bool, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	otherInfo, err := other.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	if info.Size() != otherInfo.Size() {
+		return false, nil
+	}
+
+	if p.absPath == other.absPath && p.fs == other.fs || os.SameFile(info, otherInfo) {
+		return true, nil
+	}
+
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	otherFile, err := other.fs.Open(other.absPath)
+	if err != nil {
+		return false, err
+	}
+	defer otherFile.Close()
+
+	return readersEqual(file, otherFile)
+}
+
+// compareBufferSize is the chunk size used by readersEqual.
+const compareBufferSize = 32 * 1024
+
+// readersEqual compares two streams chunk by chunk.
+func readersEqual(a, b io.Reader) (bool, error) {
+	bufA := make([]byte, compareBufferSize)
+	bufB := make([]byte, compareBufferSize)
+
+	for {
+		nA, errA := io.ReadFull(a, bufA)
+		if errA != nil && !errors.Is(errA, io.EOF) && !errors.Is(errA, io.ErrUnexpectedEOF) {
+			return false, errA
+		}
+
+		nB, errB := io.ReadFull(b, bufB)
+		if errB != nil && !errors.Is(errB, io.EOF) && !errors.Is(errB, io.ErrUnexpectedEOF) {
+			return false, errB
+		}
+
+		if !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return false, nil
+		}
+
+		if errA != nil || errB != nil {
+			return errA != nil && errB != nil, nil
+		}
+	}
+}
+
+// ReadRange reads length bytes starting at offset from the file, without loading the whole file.
+//
+// Parameters:
+//   - offset: The position of the first byte to read.
+//   - length: The number of bytes to read.
+//
+// Returns:
+//   - []byte: The bytes in the range [offset, offset+length).
+//   - error: An error wrapping ErrInvalidRange if offset or length is negative or offset is past EOF,
+//     an error wrapping io.ErrUnexpectedEOF if the file ends before length bytes were read,
+//     or any error raised while opening or seeking the file.
+//
+// Example usage:
+//
+//	record, err := path.ReadRange(1024, 128)
+//	if err != nil {
+//		// handle error
+//	}
+func (p *FsPath) ReadRange(offset, length int64) ([]byte, error) {
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("%w: offset %d, length %d", ErrInvalidRange, offset, length)
+	}
+
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > info.Size() {
+		return nil, fmt.Errorf("%w: offset %d is past end of file (size %d)", ErrInvalidRange, offset, info.Size())
+	}
+
+	// check before allocating, so a huge length on a small file fails instead of exhausting memory
+	if available := info.Size() - offset; length > available {
+		return nil, fmt.Errorf("%w: only %d of %d bytes available at offset %d", io.ErrUnexpectedEOF, available, length, offset)
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, length)
+
+	read, err := io.ReadFull(io.LimitReader(file, length), buf)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: read %d of %d bytes at offset %d", io.ErrUnexpectedEOF, read, length, offset)
+		}
+
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it over
+// the destination, so readers never observe a partially written file.
+// The mode of an existing destination is preserved; new files get FileMode644.
+// An error wrapping ErrDirNotSynced means the rename succeeded and only the final
+// directory sync failed.
+func (p *FsPath) writeFileAtomic(data []byte) error {
+	_, err := p.writeAtomicFrom(bytes.NewReader(data))
+	return err
+}
+
+// writeAtomicFrom is writeFileAtomic for a stream: r is copied into the temporary file, which
+// is removed if reading, writing or renaming fails. It returns the number of bytes copied.
+func (p *FsPath) writeAtomicFrom(r io.Reader) (int64, error) {
+	if err := p.MkParentDir(); err != nil {
+		return 0, err
+	}
+
+	mode := FileMode644
+	if info, err := p.Stat(); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := afero.TempFile(p.fs, filepath.Dir(p.absPath), "."+p.Name+".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+
+	tmpName := tmp.Name()
+
+	cleanup := func(written int64, err error) (int64, error) {
+		tmp.Close()
+		_ = p.fs.Remove(tmpName)
+
+		return written, err
+	}
+
+	written, err := io.Copy(tmp, r)
+	if err != nil {
+		return cleanup(written, err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return cleanup(written, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return cleanup(written, err)
+	}
+
+	if err := p.fs.Chmod(tmpName, mode); err != nil {
+		return cleanup(written, err)
+	}
+
+	if err := p.fs.Rename(tmpName, p.absPath); err != nil {
+		return cleanup(written, err)
+	}
+
+	audit(AuditWrite, p.absPath)
+
+	return written, p.syncDirAfterRename()
+}
+
+// EditAtomic edits the file through a temporary copy and swaps it in only if the edit succeeds.
+//
+// The file is copied to a temporary file in the same directory and fn is called with that copy.
+// If fn returns nil, the copy is renamed over the original, keeping the original permissions;
+// otherwise the copy is removed and the original is left untouched.
+//
+// Parameters:
+//   - fn: A function that edits tmp in place, e.g. with WriteText or AppendText.
+//
+// Returns:
+//   - error: The error returned by fn, or an error if the file cannot be copied or replaced.
+//     An error wrapping ErrDirNotSynced means the edit was already swapped in and only the
+//     directory sync failed.
+//
+// Example:
+//
+//	err := Path("/etc/app/config.yaml").EditAtomic(func(tmp *FsPath) error {
+//	    if err := tmp.AppendText("debug: true\n"); err != nil {
+//	        return err
+//	    }
+//	    if !confirm() {
+//	        return errors.New("aborted by user")
+//	    }
+//	    return nil
+//	})
+func (p *FsPath) EditAtomic(fn func(tmp *FsPath) error) error {
+	info, err := p.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := afero.TempFile(p.fs, filepath.Dir(p.absPath), "."+p.Name+".edit-*")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := p.withSameFs(tmp.Name())
+
+	cleanup := func(err error) error {
+		_ = p.fs.Remove(tmpPath.absPath)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return cleanup(err)
+	}
+
+	if err := p.Copy(tmpPath.absPath); err != nil {
+		return cleanup(err)
+	}
+
+	if err := fn(tmpPath); err != nil {
+		return cleanup(err)
+	}
+
+	if err := p.fs.Chmod(tmpPath.absPath, info.Mode().Perm()); err != nil {
+		return cleanup(err)
+	}
+
+	if err := p.fs.Rename(tmpPath.absPath, p.absPath); err != nil {
+		return cleanup(err)
+	}
+
+	p.Invalidate()
+	audit(AuditWrite, p.absPath)
+
+	return p.syncDirAfterRename()
+}
+
+// DeduplicateLines removes duplicate lines from the file and writes the result back atomically.
+//
+// Lines are read like GetLines, so trailing "\r" characters are trimmed before comparison.
+//
+// Parameters:
+//   - keepOrder: If true, the first occurrence of each line is kept in its original position;
+//     if false, the unique lines are written back sorted.
+//   - collapseEmpty: Optional; if false, empty lines are all kept instead of being deduplicated
+//     like other lines. Defaults to true.
+//
+// Returns:
+//   - removed: The number of lines removed.
+//   - err: An error if the file cannot be read or written.
+//
+// Example usage:
+//
+//	removed, err := Path("allowlist.txt").DeduplicateLines(true)
+//	if err != nil {
+//		// handle error
+//	}
+//	fmt.Printf("removed %d duplicates\n", removed)
+func (p *FsPath) DeduplicateLines(keepOrder bool, collapseEmpty ...bool) (removed int, err error) {
+	collapse := true
+	if len(collapseEmpty) > 0 {
+		collapse = collapseEmpty[0]
+	}
+
+	lines, err := p.GetLines()
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]struct{}, len(lines))
+	unique := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if line == "" && !collapse {
+			unique = append(unique, line)
+			continue
+		}
+
+		if _, ok := seen[line]; ok {
+			continue
+		}
+
+		seen[line] = struct{}{}
+		unique = append(unique, line)
+	}
+
+	if !keepOrder {
+		sort.Strings(unique)
+	}
+
+	content := strings.Join(unique, "\n")
+	if len(unique) > 0 {
+		content += "\n"
+	}
+
+	if err := p.writeFileAtomic([]byte(content)); err != nil {
+		return 0, err
+	}
+
+	return len(lines) - len(unique), nil
+}
@@ -1,8 +1,19 @@
 package pathlib
 
 import (
+	"bytes"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
+	"testing/iotest"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/spf13/afero"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
 )
 
 func (s *PathSuite) TestWriteText() {
@@ -117,6 +128,31 @@ func (s *PathSuite) TestAppendBytes() {
 	s.Equal(append([]byte(_testContent), additionalContent...), content)
 }
 
+func (s *PathSuite) TestAppender() {
+	path := s.createTempFile("appender.txt", _testContent)
+
+	w, err := Path(path).Appender()
+	s.Require().NoError(err)
+
+	for i := 0; i < 3; i++ {
+		_, err = io.WriteString(w, "line\n")
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(w.Close())
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(_testContent+"line\nline\nline\n", string(content))
+
+	newFile := Path(s.tempDir).Join("nested", "new.log")
+	w, err = newFile.Appender()
+	s.Require().NoError(err)
+	_, err = w.Write([]byte("first"))
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	s.Equal("first", newFile.MustReadText())
+}
+
 func (s *PathSuite) TestMustAppendText() {
 	path := s.createTempFile("mustappendtext.txt", _testContent)
 	file := Path(path)
@@ -171,3 +207,361 @@ func (s *PathSuite) TestCalculateMD5() {
 	_, err = dirPath.GetMD5()
 	s.Require().Error(err)
 }
+
+func (s *PathSuite) TestReadRange() {
+	file := Path(s.createTempFile("range.txt", "0123456789"))
+
+	data, err := file.ReadRange(3, 4)
+	s.Require().NoError(err)
+	s.Equal("3456", string(data))
+
+	data, err = file.ReadRange(0, 0)
+	s.Require().NoError(err)
+	s.Empty(data)
+
+	_, err = file.ReadRange(20, 1)
+	s.Require().ErrorIs(err, ErrInvalidRange)
+
+	_, err = file.ReadRange(-1, 1)
+	s.Require().ErrorIs(err, ErrInvalidRange)
+
+	_, err = file.ReadRange(8, 5)
+	s.Require().ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// a huge length is rejected before allocating the buffer
+	_, err = file.ReadRange(0, math.MaxInt64)
+	s.Require().ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func (s *PathSuite) TestGetStringNoBOM() {
+	const content = "name,city\n张三,北京\n"
+
+	plain := Path(s.createTempFile("plain.csv", content))
+	utf8BOM := Path(s.createTempFile("utf8.csv", "\xEF\xBB\xBF"+content))
+
+	utf16LE := []byte{0xFF, 0xFE}
+	for _, r := range utf16.Encode([]rune(content)) {
+		utf16LE = append(utf16LE, byte(r), byte(r>>8))
+	}
+
+	utf16File := Path(filepath.Join(s.tempDir, "utf16.csv"))
+	s.Require().NoError(utf16File.WriteBytes(utf16LE))
+
+	for _, file := range []*FsPath{plain, utf8BOM, utf16File} {
+		got, err := file.GetStringNoBOM()
+		s.Require().NoError(err, file.Name)
+		s.Equal(content, got, file.Name)
+
+		got, err = file.ReadTextNoBOM()
+		s.Require().NoError(err, file.Name)
+		s.Equal(content, got, file.Name)
+	}
+}
+
+func (s *PathSuite) TestWriteEncodedReadDecoded() {
+	const content = "中文编码测试: GBK round trip\n"
+
+	file := Path(filepath.Join(s.tempDir, "gbk.txt"))
+	s.Require().NoError(file.WriteEncoded(content, simplifiedchinese.GBK))
+
+	raw := file.MustReadBytes()
+	s.NotEqual([]byte(content), raw)
+	s.False(utf8.Valid(raw))
+
+	got, err := file.ReadDecoded(simplifiedchinese.GBK)
+	s.Require().NoError(err)
+	s.Equal(content, got)
+
+	s.Require().Error(file.WriteEncoded("emoji 😀", charmap.ISO8859_1))
+}
+
+func (s *PathSuite) TestContentEquals() {
+	content := strings.Repeat("0123456789", 10000)
+
+	original := Path(s.createTempFile("original.txt", content))
+	identical := Path(s.createTempFile("identical.txt", content))
+	lastByte := Path(s.createTempFile("last_byte.txt", content[:len(content)-1]+"X"))
+	shorter := Path(s.createTempFile("shorter.txt", content[:len(content)-1]))
+
+	equal, err := original.ContentEquals(identical)
+	s.Require().NoError(err)
+	s.True(equal)
+
+	equal, err = original.ContentEquals(lastByte)
+	s.Require().NoError(err)
+	s.False(equal)
+
+	equal, err = original.ContentEquals(shorter)
+	s.Require().NoError(err)
+	s.False(equal)
+
+	equal, err = original.ContentEquals(Path(original.String()))
+	s.Require().NoError(err)
+	s.True(equal)
+
+	_, err = original.ContentEquals(Path(filepath.Join(s.tempDir, "missing.txt")))
+	s.Require().ErrorIs(err, os.ErrNotExist)
+}
+
+// unseekableFs wraps an afero.Fs so that opened files fail to seek.
+type unseekableFs struct {
+	afero.Fs
+}
+
+type unseekableFile struct {
+	afero.File
+}
+
+func (fs unseekableFs) Open(name string) (afero.File, error) {
+	file, err := fs.Fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return unseekableFile{file}, nil
+}
+
+func (unseekableFile) Seek(int64, int) (int64, error) {
+	return 0, errTest
+}
+
+func (s *PathSuite) TestReadSeeker() {
+	file := Path(s.createTempFile("media.bin", "0123456789abcdef"))
+
+	reader, size, err := file.ReadSeeker()
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	s.Equal(int64(16), size)
+
+	offset, err := reader.Seek(10, io.SeekStart)
+	s.Require().NoError(err)
+	s.Equal(int64(10), offset)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(reader, buf)
+	s.Require().NoError(err)
+	s.Equal("abcd", string(buf))
+
+	_, err = reader.Seek(-3, io.SeekEnd)
+	s.Require().NoError(err)
+
+	rest, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Equal("def", string(rest))
+
+	file.fs = unseekableFs{file.fs}
+	_, _, err = file.ReadSeeker()
+	s.Require().ErrorIs(err, ErrNotSeekable)
+}
+
+func (s *PathSuite) TestWriteFromReader() {
+	file := Path(s.tempDir).Join("downloads", "page.html")
+
+	written, err := file.WriteFromReader(strings.NewReader("<html>hello</html>"))
+	s.Require().NoError(err)
+	s.Equal(int64(18), written)
+	s.Equal("<html>hello</html>", file.MustReadText())
+
+	written, err = file.WriteFromReader(strings.NewReader("short"))
+	s.Require().NoError(err)
+	s.Equal(int64(5), written)
+	s.Equal("short", file.MustReadText())
+
+	_, err = file.WriteFromReader(iotest.ErrReader(errTest))
+	s.Require().ErrorIs(err, errTest)
+}
+
+func (s *PathSuite) TestWriteFromReaderAtomic() {
+	dir := Path(s.tempDir).Join("downloads")
+	file := dir.Join("dataset.bin")
+
+	// fails after 1000 bytes, like a dropped connection
+	broken := io.MultiReader(bytes.NewReader(bytes.Repeat([]byte("x"), 1000)), iotest.ErrReader(errTest))
+
+	written, err := file.WriteFromReaderAtomic(broken)
+	s.Require().ErrorIs(err, errTest)
+	s.Equal(int64(1000), written)
+	s.NoFileExists(file.String())
+
+	entries, err := os.ReadDir(dir.String())
+	s.Require().NoError(err)
+	s.Empty(entries, "the temporary file must be removed")
+
+	written, err = file.WriteFromReaderAtomic(strings.NewReader("complete"))
+	s.Require().NoError(err)
+	s.Equal(int64(8), written)
+	s.Equal("complete", file.MustReadText())
+
+	// a failed rewrite keeps the previous content
+	_, err = file.WriteFromReaderAtomic(io.MultiReader(strings.NewReader("part"), iotest.ErrReader(errTest)))
+	s.Require().ErrorIs(err, errTest)
+	s.Equal("complete", file.MustReadText())
+}
+
+func (s *PathSuite) TestTeeReader() {
+	payload := bytes.Repeat([]byte("stream-data\n"), 10000)
+	file := Path(s.tempDir).Join("nested", "tee.bin")
+
+	tee, err := file.TeeReader(bytes.NewReader(payload))
+	s.Require().NoError(err)
+
+	read, err := io.ReadAll(tee)
+	s.Require().NoError(err)
+	s.Require().NoError(tee.Close())
+
+	s.Equal(payload, read)
+	s.Equal(payload, file.MustReadBytes())
+}
+
+func (s *PathSuite) TestDeduplicateLines() {
+	const content = "b\r\na\nb\n\nc\na\n\n"
+
+	file := Path(s.createTempFile("allow.txt", content))
+	s.Require().NoError(file.Chmod(0o600))
+
+	removed, err := file.DeduplicateLines(true)
+	s.Require().NoError(err)
+	s.Equal(2, removed)
+	s.Equal("b\na\n\nc\n", file.MustReadText())
+
+	info, err := file.Stat()
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	file.MustWriteText(content)
+	removed, err = file.DeduplicateLines(false)
+	s.Require().NoError(err)
+	s.Equal(2, removed)
+	s.Equal("\na\nb\nc\n", file.MustReadText())
+
+	file.MustWriteText("a\n\na\n\nb\n")
+	removed, err = file.DeduplicateLines(true, false)
+	s.Require().NoError(err)
+	s.Equal(1, removed)
+	s.Equal("a\n\n\nb\n", file.MustReadText())
+
+	// no temp files are left behind
+	entries, err := os.ReadDir(s.tempDir)
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
+
+func (s *PathSuite) TestWriteJSONAtomic() {
+	file := Path(s.createTempFile("state.json", `{"offset":1}`))
+	s.Require().NoError(file.Chmod(0o600))
+
+	s.Require().NoError(file.WriteJSONAtomic(map[string]int{"offset": 2}))
+	s.Equal("{\n  \"offset\": 2\n}", file.MustReadText())
+
+	info, err := file.Stat()
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	// marshal failure
+	err = file.WriteJSONAtomic(map[string]interface{}{"bad": make(chan int)})
+	s.Require().Error(err)
+
+	// rename failure
+	file.fs = crossDeviceFs{afero.NewOsFs()}
+	err = file.WriteJSONAtomic(map[string]int{"offset": 3})
+	s.Require().Error(err)
+
+	s.Equal("{\n  \"offset\": 2\n}", file.MustReadText())
+
+	entries, err := os.ReadDir(s.tempDir)
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
+
+func (s *PathSuite) TestEditAtomic() {
+	path := s.createTempFile("edit.txt", _testContent)
+	s.Require().NoError(os.Chmod(path, 0o600))
+	file := Path(path)
+
+	err := file.EditAtomic(func(tmp *FsPath) error {
+		s.NotEqual(file.String(), tmp.String())
+		s.Equal(_testContent, tmp.MustReadText())
+		s.Require().NoError(tmp.WriteText("discarded"))
+
+		return errTest
+	})
+	s.ErrorIs(err, errTest)
+	s.Equal(_testContent, file.MustReadText())
+
+	err = file.EditAtomic(func(tmp *FsPath) error {
+		return tmp.AppendText(" edited")
+	})
+	s.Require().NoError(err)
+	s.Equal(_testContent+" edited", file.MustReadText())
+
+	info, err := os.Stat(path)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(s.tempDir)
+	s.Require().NoError(err)
+	for _, entry := range entries {
+		s.NotContains(entry.Name(), ".edit-", "temporary copies should be cleaned up")
+	}
+
+	s.Error(Path(s.tempDir).Join("missing.txt").EditAtomic(func(*FsPath) error { return nil }))
+}
+
+type jsonConfig struct {
+	Name     string            `json:"name"`
+	Port     int               `json:"port"`
+	LogLevel string            `json:"log_level"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func (s *PathSuite) TestGetJSONOnto() {
+	file := Path(s.createTempFile("partial.json", `{"port": 9090, "labels": {"env": "prod"}}`))
+
+	config := jsonConfig{Name: "app", Port: 8080, LogLevel: "info", Labels: map[string]string{"team": "core"}}
+	s.Require().NoError(file.GetJSONOnto(&config))
+
+	s.Equal(jsonConfig{
+		Name:     "app",
+		Port:     9090,
+		LogLevel: "info",
+		Labels:   map[string]string{"team": "core", "env": "prod"},
+	}, config)
+}
+
+func (s *PathSuite) TestLoadConfig() {
+	file := Path(s.createTempFile("config.json", `{"log_level": "debug", "labels": {"env": "prod"}}`))
+	defaults := jsonConfig{Name: "app", Port: 8080, LogLevel: "info", Labels: map[string]string{"team": "core"}}
+
+	var config jsonConfig
+	s.Require().NoError(file.LoadConfig(&config, defaults))
+
+	s.Equal("app", config.Name)
+	s.Equal(8080, config.Port)
+	s.Equal("debug", config.LogLevel)
+	s.Equal(map[string]string{"team": "core", "env": "prod"}, config.Labels)
+	s.Equal(map[string]string{"team": "core"}, defaults.Labels, "defaults must not be modified")
+
+	s.Error(Path(s.tempDir).Join("missing.json").LoadConfig(&config, &defaults))
+}
+
+func (s *PathSuite) TestWriteAt() {
+	file := Path(s.createTempFile("patch.bin", "HEADv1-payload"))
+
+	written, err := file.WriteAt([]byte("v2"), 4)
+	s.Require().NoError(err)
+	s.Equal(2, written)
+	s.Equal("HEADv2-payload", file.MustReadText())
+
+	_, err = file.WriteAt([]byte("!"), 16)
+	s.Require().NoError(err)
+	s.Equal("HEADv2-payload\x00\x00!", file.MustReadText())
+
+	_, err = file.WriteAt([]byte("x"), -1)
+	s.ErrorIs(err, ErrInvalidRange)
+
+	created := Path(s.tempDir).Join("new", "sparse.bin")
+	_, err = created.WriteAt([]byte("ab"), 2)
+	s.Require().NoError(err)
+	s.Equal([]byte{0, 0, 'a', 'b'}, created.MustReadBytes())
+}
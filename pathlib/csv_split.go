@@ -0,0 +1,160 @@
+package pathlib
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+var ErrInvalidRowCount = errors.New("invalid row count")
+
+// SplitCSV splits the CSV file into shards of at most rowsPerFile data rows each, repeating
+// the header row at the top of every shard, e.g. for parallel processing.
+//
+// The file is streamed record by record, so only the current record is held in memory.
+// Records are parsed like CSVGetSlices: ',' separates fields and lines starting with '#' are
+// skipped. Shards are named after the file with a 1-based, zero-padded number, e.g.
+// "data_001.csv", "data_002.csv", and existing files with those names are overwritten.
+//
+// Parameters:
+//   - rowsPerFile: The maximum number of data rows per shard, not counting the header.
+//   - destDir: The directory to write the shards to. It is created if it doesn't exist.
+//
+// Returns:
+//   - []*FsPath: The shards in order. Empty if the file has no data rows.
+//   - error: An error wrapping ErrInvalidRowCount if rowsPerFile is not positive, or an error
+//     if the file cannot be read or parsed, or a shard cannot be written.
+//
+// Example usage:
+//
+//	shards, err := Path("/data/users.csv").SplitCSV(10000, "/data/shards")
+//	if err != nil {
+//		// handle error
+//	}
+//	for _, shard := range shards {
+//		go process(shard)
+//	}
+func (p *FsPath) SplitCSV(rowsPerFile int, destDir string) ([]*FsPath, error) {
+	if rowsPerFile <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRowCount, rowsPerFile)
+	}
+
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = SepRuneCsv
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []*FsPath{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.fs.MkdirAll(destDir, DefaultDirMode); err != nil {
+		return nil, err
+	}
+
+	var (
+		shards  = []*FsPath{}
+		current *csvShard
+		rows    = 0
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, closeShard(current, err)
+		}
+
+		if current == nil || rows == rowsPerFile {
+			if err := closeShard(current, nil); err != nil {
+				return nil, err
+			}
+
+			name := fmt.Sprintf("%s_%03d%s", p.Stem, len(shards)+1, p.Suffix)
+
+			current, err = p.createCSVShard(filepath.Join(destDir, name), header)
+			if err != nil {
+				return nil, err
+			}
+
+			shards = append(shards, current.path)
+			rows = 0
+		}
+
+		if err := current.writer.Write(record); err != nil {
+			return nil, closeShard(current, err)
+		}
+
+		rows++
+	}
+
+	if err := closeShard(current, nil); err != nil {
+		return nil, err
+	}
+
+	return shards, nil
+}
+
+// csvShard is an open output file of SplitCSV.
+type csvShard struct {
+	path   *FsPath
+	file   afero.File
+	writer *csv.Writer
+}
+
+// createCSVShard creates the shard file at name and writes the header row to it.
+func (p *FsPath) createCSVShard(name string, header []string) (*csvShard, error) {
+	file, err := p.fs.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	shard := &csvShard{path: p.withSameFs(name), file: file, writer: csv.NewWriter(file)}
+	shard.writer.Comma = SepRuneCsv
+
+	if err := shard.writer.Write(header); err != nil {
+		return nil, closeShard(shard, err)
+	}
+
+	return shard, nil
+}
+
+// closeShard flushes and closes shard, if any, and returns err or else the first flush or close error.
+func closeShard(shard *csvShard, err error) error {
+	if shard == nil {
+		return err
+	}
+
+	shard.writer.Flush()
+
+	if err == nil {
+		err = shard.writer.Error()
+	}
+
+	if closeErr := shard.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		audit(AuditWrite, shard.path.absPath)
+	}
+
+	return err
+}
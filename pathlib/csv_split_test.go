@@ -0,0 +1,26 @@
+package pathlib
+
+func (s *PathSuite) TestSplitCSV() {
+	content := "id,name\n# comment\n1,a\n2,b\n3,c\n4,d\n5,e\n6,f\n7,\"g, h\"\n"
+	file := Path(s.createTempFile("data.csv", content))
+	destDir := Path(s.tempDir).Join("shards")
+
+	shards, err := file.SplitCSV(3, destDir.String())
+	s.Require().NoError(err)
+	s.Equal([]string{"data_001.csv", "data_002.csv", "data_003.csv"}, names(shards))
+
+	s.Equal("id,name\n1,a\n2,b\n3,c\n", shards[0].MustReadText())
+	s.Equal("id,name\n4,d\n5,e\n6,f\n", shards[1].MustReadText())
+	s.Equal("id,name\n7,\"g, h\"\n", shards[2].MustReadText())
+
+	headerOnly := Path(s.createTempFile("header.csv", "id,name\n"))
+	shards, err = headerOnly.SplitCSV(3, destDir.String())
+	s.Require().NoError(err)
+	s.Empty(shards)
+
+	_, err = file.SplitCSV(0, destDir.String())
+	s.ErrorIs(err, ErrInvalidRowCount)
+
+	_, err = Path(s.createTempFile("broken.csv", "id,name\n1,a,extra\n")).SplitCSV(3, destDir.String())
+	s.Error(err)
+}
@@ -0,0 +1,122 @@
+package pathlib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// DirHash computes a deterministic SHA256 digest over the directory tree at the current path.
+//
+// Every entry below the directory contributes a line with its relative slash-separated path,
+// its mode and, for regular files, the SHA256 of its content (for symlinks, the link target).
+// Entries are folded in sorted path order, so two trees with the same structure, permissions
+// and content always produce the same hash, wherever they are located. The mode of the root
+// directory itself and modification times are not part of the hash.
+//
+// Returns:
+//   - string: The hex-encoded SHA256 digest.
+//   - error: An error wrapping ErrNotDirectory if the path is not a directory,
+//     or any error encountered while walking or reading files.
+//
+// Example:
+//
+//	before, _ := Path("/srv/site").DirHash()
+//	rebuild()
+//	after, _ := Path("/srv/site").DirHash()
+//	if before != after {
+//	    fmt.Println("site changed")
+//	}
+func (p *FsPath) DirHash() (string, error) {
+	if !p.IsDir() {
+		return "", fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	type hashEntry struct {
+		relPath string
+		info    fs.FileInfo
+	}
+
+	var entries []hashEntry
+
+	err := p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if relPath != "." {
+			entries = append(entries, hashEntry{relPath: filepath.ToSlash(relPath), info: info})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].relPath < entries[j].relPath
+	})
+
+	hash := sha256.New()
+
+	for _, entry := range entries {
+		sum, err := p.entryDigest(entry.relPath, entry.info)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s %s %s\n", entry.info.Mode(), sum, entry.relPath)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// entryDigest returns the hex SHA256 of a regular file's content or a symlink's target,
+// and "-" for other entries such as directories.
+func (p *FsPath) entryDigest(relPath string, info fs.FileInfo) (string, error) {
+	fullPath := filepath.Join(p.absPath, filepath.FromSlash(relPath))
+
+	switch {
+	case info.Mode().IsRegular():
+		return p.withSameFs(fullPath).sha256Hex()
+	case info.Mode()&fs.ModeSymlink != 0:
+		reader, ok := p.fs.(afero.LinkReader)
+		if !ok {
+			return "-", nil
+		}
+
+		link, err := reader.ReadlinkIfPossible(fullPath)
+		if err != nil {
+			return "", err
+		}
+
+		sum := sha256.Sum256([]byte(filepath.ToSlash(link)))
+
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "-", nil
+	}
+}
+
+// sha256Hex returns the hex-encoded SHA256 of the file content.
+func (p *FsPath) sha256Hex() (string, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,36 @@
+package pathlib
+
+func (s *PathSuite) TestDirHash() {
+	build := func(name, content string) *FsPath {
+		dir := Path(s.tempDir).Join(name)
+		s.Require().NoError(dir.Join("a.txt").WriteText("alpha"))
+		s.Require().NoError(dir.Join("sub", "b.txt").WriteText(content))
+		s.Require().NoError(dir.Join("empty").Mkdirs())
+
+		return dir
+	}
+
+	first := build("first", "bravo")
+	second := build("second", "bravo")
+	changed := build("changed", "bravO")
+
+	firstHash, err := first.DirHash()
+	s.Require().NoError(err)
+	s.Len(firstHash, 64)
+
+	secondHash, err := second.DirHash()
+	s.Require().NoError(err)
+	s.Equal(firstHash, secondHash)
+
+	changedHash, err := changed.DirHash()
+	s.Require().NoError(err)
+	s.NotEqual(firstHash, changedHash)
+
+	s.Require().NoError(second.Join("sub", "c.txt").Touch())
+	secondHash, err = second.DirHash()
+	s.Require().NoError(err)
+	s.NotEqual(firstHash, secondHash, "a new empty file changes the hash")
+
+	_, err = first.Join("a.txt").DirHash()
+	s.ErrorIs(err, ErrNotDirectory)
+}
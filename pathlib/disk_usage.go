@@ -0,0 +1,37 @@
+package pathlib
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotSupported      = errors.New("operation not supported on this platform")
+	ErrInsufficientSpace = errors.New("insufficient disk space")
+)
+
+// DiskUsage reports the size of the file system containing this path.
+//
+// The path itself must exist; it may be a file or a directory.
+//
+// Returns:
+//   - total: The total size of the file system in bytes.
+//   - free: The number of free bytes, including blocks reserved for the superuser.
+//   - available: The number of bytes available to unprivileged users.
+//   - err: ErrNotSupported on platforms without a statfs equivalent,
+//     or the error returned by the system call.
+//
+// Example usage:
+//
+//	_, _, available, err := Path("/data").DiskUsage()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if available < neededBytes {
+//	    log.Fatal("not enough space")
+//	}
+//
+// Note: DiskUsage always queries the operating system, regardless of the afero file system
+// backing this FsPath.
+func (p *FsPath) DiskUsage() (total, free, available uint64, err error) {
+	return diskUsage(p.absPath)
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !windows
+
+package pathlib
+
+func diskUsage(string) (total, free, available uint64, err error) {
+	return 0, 0, 0, ErrNotSupported
+}
@@ -0,0 +1,34 @@
+package pathlib
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+func (s *PathSuite) TestDiskUsage() {
+	total, free, available, err := Path(s.tempDir).DiskUsage()
+	if errors.Is(err, ErrNotSupported) {
+		s.T().Skip("DiskUsage is not supported on this platform")
+	}
+
+	s.Require().NoError(err)
+	s.Positive(total)
+	s.LessOrEqual(free, total)
+	// available excludes reserved blocks, so it never exceeds free by more than rounding
+	s.LessOrEqual(available, free+4096)
+
+	_, _, _, err = Path(s.tempDir).Join("missing").DiskUsage()
+	s.Require().Error(err)
+}
+
+func (s *PathSuite) TestUnzipWithDiskSpaceCheck() {
+	archive := Path(s.tempDir).Join("small.zip")
+	s.Require().NoError(CompressReaderToZip(map[string]io.Reader{
+		"a.txt": strings.NewReader("tiny"),
+	}, archive.absPath))
+
+	destDir := Path(s.tempDir).Join("out")
+	s.Require().NoError(archive.Unzip(destDir.absPath, WithDiskSpaceCheck()))
+	s.Equal("tiny", destDir.Join("small", "a.txt").MustReadText())
+}
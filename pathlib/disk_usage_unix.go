@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package pathlib
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func diskUsage(path string) (total, free, available uint64, err error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, 0, 0, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+
+	return stat.Blocks * blockSize, stat.Bfree * blockSize, stat.Bavail * blockSize, nil
+}
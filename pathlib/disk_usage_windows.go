@@ -0,0 +1,18 @@
+package pathlib
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+func diskUsage(path string) (total, free, available uint64, err error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &available, &total, &free); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return total, free, available, nil
+}
@@ -0,0 +1,155 @@
+package pathlib
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// FindDuplicates walks the directory tree and groups regular files with identical content.
+//
+// Files are first bucketed by size, and only files sharing their size with another file are
+// hashed with SHA256, so unique sizes are never read. Empty files and symlinks are ignored.
+//
+// Returns:
+//   - map[string][]*FsPath: The groups of identical files keyed by the hex SHA256 of their
+//     content. Only groups with at least two files are included, each sorted like SortByName:
+//     by file name, then by absolute path for files with the same name.
+//   - error: An error wrapping ErrNotDirectory if the path is not a directory, or any error
+//     encountered while walking or reading files.
+//
+// Example:
+//
+//	groups, err := Path("~/Downloads").Expand().FindDuplicates()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for hash, files := range groups {
+//	    fmt.Println(hash[:12], files)
+//	}
+func (p *FsPath) FindDuplicates() (map[string][]*FsPath, error) {
+	if !p.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	bySize := map[int64][]*FsPath{}
+
+	err := p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() && info.Size() > 0 {
+			bySize[info.Size()] = append(bySize[info.Size()], p.withSameFs(filepath.Join(p.absPath, relPath)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byHash := map[string][]*FsPath{}
+
+	for _, files := range bySize {
+		if len(files) < 2 {
+			continue
+		}
+
+		for _, file := range files {
+			sum, err := file.sha256Hex()
+			if err != nil {
+				return nil, err
+			}
+
+			byHash[sum] = append(byHash[sum], file)
+		}
+	}
+
+	for sum, files := range byHash {
+		if len(files) < 2 {
+			delete(byHash, sum)
+			continue
+		}
+
+		SortByName(files, false)
+	}
+
+	return byHash, nil
+}
+
+// DeduplicateByHardlink finds duplicate files like FindDuplicates and replaces every duplicate
+// with a hard link to the first file of its group (in FindDuplicates order, i.e. by name, then
+// path), reclaiming the space used by the copies.
+//
+// Each duplicate is replaced atomically: the link is created under a temporary name and renamed
+// over the duplicate. Files that are already hard links to the first file are left alone.
+//
+// Returns:
+//   - int: The number of files replaced with hard links.
+//   - error: An error wrapping ErrNotSupported if the path is not on the OS file system,
+//     or any error from FindDuplicates or while linking.
+//
+// Note: Hard-linked files share their content and metadata, so writing to one changes all of
+// them, and all take the permissions and owner of the first file. Hard links cannot cross
+// file systems.
+func (p *FsPath) DeduplicateByHardlink() (int, error) {
+	if _, ok := p.fs.(*afero.OsFs); !ok {
+		return 0, fmt.Errorf("%w: hard links require the OS file system", ErrNotSupported)
+	}
+
+	groups, err := p.FindDuplicates()
+	if err != nil {
+		return 0, err
+	}
+
+	replaced := 0
+
+	for _, files := range groups {
+		original := files[0]
+
+		originalInfo, err := os.Stat(original.absPath)
+		if err != nil {
+			return replaced, err
+		}
+
+		for _, duplicate := range files[1:] {
+			info, err := os.Stat(duplicate.absPath)
+			if err != nil {
+				return replaced, err
+			}
+
+			if os.SameFile(originalInfo, info) {
+				continue
+			}
+
+			if err := hardlinkOver(original.absPath, duplicate.absPath); err != nil {
+				return replaced, err
+			}
+
+			audit(AuditWrite, duplicate.absPath)
+			replaced++
+		}
+	}
+
+	return replaced, nil
+}
+
+// hardlinkOver atomically replaces target with a hard link to source.
+func hardlinkOver(source, target string) error {
+	tmp := filepath.Join(filepath.Dir(target), fmt.Sprintf(".%s.link-%d", filepath.Base(target), os.Getpid()))
+
+	if err := os.Link(source, tmp); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, target); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
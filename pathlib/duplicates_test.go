@@ -0,0 +1,54 @@
+package pathlib
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+func (s *PathSuite) TestFindDuplicates() {
+	root := Path(s.tempDir).Join("downloads")
+	s.Require().NoError(root.Join("report.pdf").WriteText("same content"))
+	s.Require().NoError(root.Join("sub", "report (1).pdf").WriteText("same content"))
+	s.Require().NoError(root.Join("other.pdf").WriteText("same length!"))
+	s.Require().NoError(root.Join("unique.txt").WriteText("unique"))
+	s.Require().NoError(root.Join("empty1").Touch())
+	s.Require().NoError(root.Join("empty2").Touch())
+
+	groups, err := root.FindDuplicates()
+	s.Require().NoError(err)
+	s.Require().Len(groups, 1)
+
+	for hash, files := range groups {
+		s.Len(hash, 64)
+		s.Equal([]string{"report (1).pdf", "report.pdf"}, names(files))
+	}
+
+	_, err = root.Join("unique.txt").FindDuplicates()
+	s.ErrorIs(err, ErrNotDirectory)
+}
+
+func (s *PathSuite) TestDeduplicateByHardlink() {
+	root := Path(s.tempDir).Join("dedup")
+	s.Require().NoError(root.Join("a.bin").WriteText("duplicate"))
+	s.Require().NoError(root.Join("b.bin").WriteText("duplicate"))
+	s.Require().NoError(root.Join("c.bin").WriteText("different"))
+
+	replaced, err := root.DeduplicateByHardlink()
+	s.Require().NoError(err)
+	s.Equal(1, replaced)
+
+	a, err := os.Stat(root.Join("a.bin").String())
+	s.Require().NoError(err)
+	b, err := os.Stat(root.Join("b.bin").String())
+	s.Require().NoError(err)
+	s.True(os.SameFile(a, b))
+	s.Equal("duplicate", root.Join("b.bin").MustReadText())
+
+	replaced, err = root.DeduplicateByHardlink()
+	s.Require().NoError(err)
+	s.Zero(replaced, "already linked files are left alone")
+
+	_, err = Path("/mem").WithFs(afero.NewMemMapFs()).DeduplicateByHardlink()
+	s.ErrorIs(err, ErrNotSupported)
+}
@@ -1,9 +1,16 @@
 package pathlib
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"io/fs"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/spf13/afero"
 )
@@ -87,6 +94,53 @@ func (p *FsPath) ListFilesWithGlob(pattern string) ([]string, error) {
 	return ListFilesWithGlob(p.fs, p.Dir().absPath, pattern)
 }
 
+// ListRelative lists the files matching pattern like the ListFilesWithGlob method, and returns
+// them as paths relative to base, e.g. to write a manifest of a build output directory.
+//
+// Every match must lie within base: a match outside it is an error rather than being silently
+// dropped from the listing.
+//
+// Parameters:
+//   - base: The directory the results are relative to, expanded and made absolute first.
+//   - pattern: The glob pattern to match files against. If empty, defaults to "*".
+//
+// Returns:
+//   - []string: The relative paths of the matches, sorted.
+//   - error: An error if the glob pattern is malformed, or an error wrapping ErrIllegalFilePath
+//     if a match is outside base.
+//
+// Example usage:
+//
+//	dist := Path("/srv/build/dist/assets")
+//	files, err := dist.ListRelative("/srv/build/dist", "*.js")
+//	// files: ["assets/app.js", "assets/vendor.js"]
+func (p *FsPath) ListRelative(base string, pattern string) ([]string, error) {
+	baseAbs, err := ResolveAbsPath(base)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := p.ListFilesWithGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	relPaths := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		rel, err := filepath.Rel(baseAbs, match)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%w: %s is outside %s", ErrIllegalFilePath, match, baseAbs)
+		}
+
+		relPaths = append(relPaths, rel)
+	}
+
+	sort.Strings(relPaths)
+
+	return relPaths, nil
+}
+
 // ListFilesWithGlob lists files in the specified directory matching the given pattern.
 //
 // This function uses the provided file system (fs) to perform the glob operation.
@@ -130,6 +184,49 @@ func ListFilesWithGlob(fs afero.Fs, rootDir, pattern string) ([]string, error) {
 	return afero.Glob(fs, filepath.Join(Expand(rootDir), pattern))
 }
 
+// Resolve expands a glob pattern against the directory and returns the matching paths.
+//
+// "~" and environment variables in the pattern are expanded with Expand first. A pattern
+// that is still relative afterwards is taken relative to the receiver, while an absolute
+// pattern is used as is. The results are backed by the receiver's file system.
+//
+// Parameters:
+//   - pattern: A glob pattern as accepted by filepath.Match, e.g. "*.txt", "logs/*/app.log"
+//     or "$HOME/notes/*.md".
+//
+// Returns:
+//   - []*FsPath: The matching paths in lexical order; empty if nothing matches.
+//   - error: An error if the pattern is malformed (filepath.ErrBadPattern).
+//
+// Example:
+//
+//	project := Path("/srv/project")
+//	configs, err := project.Resolve(userPattern)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, config := range configs {
+//	    fmt.Println(config)
+//	}
+func (p *FsPath) Resolve(pattern string) ([]*FsPath, error) {
+	pattern = Expand(pattern)
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(p.absPath, pattern)
+	}
+
+	matches, err := afero.Glob(p.fs, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]*FsPath, 0, len(matches))
+	for _, match := range matches {
+		paths = append(paths, p.withSameFs(match))
+	}
+
+	return paths, nil
+}
+
 // WalkFunc is the type of the function called for each file or directory visited by Walk.
 // It's the same as filepath.WalkFunc but uses afero.Fs.
 type WalkFunc func(path string, info fs.FileInfo, err error) error
@@ -193,3 +290,378 @@ func (p *FsPath) Walk(walkFn WalkFunc) error {
 		return walkFn(relPath, info, err)
 	})
 }
+
+// WalkContinue walks the file tree rooted at the FsPath like Walk, but never stops on errors:
+// it is meant for best-effort scans where a few unreadable entries shouldn't abort the whole run.
+//
+// fn is called for every visited entry, including the root. When an entry cannot be stat'ed or a
+// directory cannot be read, fn is called with the error for that entry (info may be nil if the
+// entry couldn't be stat'ed), the error is recorded and the walk moves on to the next entry.
+// The contents of an unreadable directory are skipped.
+//
+// Parameters:
+//   - fn: The function called for each file or directory, with the entry and any error for it.
+//
+// Returns:
+//   - []error: All errors encountered during the walk, in the order they occurred, or nil.
+//
+// Example usage:
+//
+//	errs := Path("/srv/data").WalkContinue(func(p *FsPath, info fs.FileInfo, err error) {
+//	    if err == nil && info.Mode().IsRegular() {
+//	        fmt.Println(p)
+//	    }
+//	})
+//	for _, err := range errs {
+//	    log.Printf("skipped: %v", err)
+//	}
+func (p *FsPath) WalkContinue(fn func(p *FsPath, info fs.FileInfo, err error)) []error {
+	var errs []error
+
+	_ = afero.Walk(p.fs, p.absPath, func(path string, info fs.FileInfo, err error) error {
+		fn(p.withSameFs(path), info, err)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+const (
+	defaultProgressInterval = 1000
+	iterDirBatchSize        = 256
+)
+
+// WalkWithProgress walks the file tree rooted at the FsPath exactly like Walk, and
+// additionally reports how many entries have been visited so far.
+//
+// Parameters:
+//   - walkFn: The function called for each file or directory, with the same semantics as in Walk.
+//   - onProgress: A callback invoked with the number of visited entries. It may be nil.
+//   - every: Optional reporting interval; onProgress is called after every N visited entries.
+//     Defaults to 1000 when omitted or not positive.
+//
+// Returns:
+//   - error: The first error returned by walkFn or encountered during traversal.
+//
+// onProgress is called synchronously from the walking goroutine, so it must not block for long.
+// When the walk finishes successfully and the total is not a multiple of the interval,
+// onProgress is called once more with the final count.
+//
+// Example usage:
+//
+//	root := Path("/path/to/root")
+//	err := root.WalkWithProgress(func(path string, info fs.FileInfo, err error) error {
+//	    return err
+//	}, func(visited int) {
+//	    fmt.Printf("\rscanned %d entries", visited)
+//	}, 500)
+//
+// Note: The traversal order and error handling are identical to Walk.
+func (p *FsPath) WalkWithProgress(walkFn WalkFunc, onProgress func(visited int), every ...int) error {
+	interval := defaultProgressInterval
+	if len(every) > 0 && every[0] > 0 {
+		interval = every[0]
+	}
+
+	visited := 0
+
+	err := p.Walk(func(path string, info fs.FileInfo, err error) error {
+		walkErr := walkFn(path, info, err)
+
+		visited++
+
+		if onProgress != nil && visited%interval == 0 {
+			onProgress(visited)
+		}
+
+		return walkErr
+	})
+	if err != nil {
+		return err
+	}
+
+	if onProgress != nil && visited%interval != 0 {
+		onProgress(visited)
+	}
+
+	return nil
+}
+
+// WalkParallel walks the file tree rooted at the FsPath and calls fn for every regular
+// file (directories are not passed to fn), spreading the calls across a pool of workers.
+//
+// Parameters:
+//   - workers: The number of concurrent workers. Zero or a negative value defaults to runtime.GOMAXPROCS(0).
+//   - fn: The function called for each file, with an FsPath for the file and its fs.FileInfo.
+//
+// Returns:
+//   - error: The first error returned by fn or encountered while enumerating the tree.
+//
+// As soon as fn returns an error, the enumeration is cancelled and files that have not
+// been started yet are skipped; calls already in progress are allowed to finish.
+//
+// Example usage:
+//
+//	root := Path("/path/to/root")
+//	err := root.WalkParallel(8, func(file *FsPath, info fs.FileInfo) error {
+//	    _, err := file.GetMD5()
+//	    return err
+//	})
+//
+// Note: Unlike Walk, the order in which files are visited is non-deterministic,
+// and fn must be safe for concurrent use.
+func (p *FsPath) WalkParallel(workers int, fn func(p *FsPath, info fs.FileInfo) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type walkJob struct {
+		path string
+		info fs.FileInfo
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	jobs := make(chan walkJob)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				if err := fn(p.withSameFs(job.path), job.info); err != nil {
+					once.Do(func() {
+						firstErr = err
+
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	walkErr := afero.Walk(p.fs, p.absPath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		select {
+		case jobs <- walkJob{path: path, info: info}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return walkErr
+}
+
+// WalkBounded walks the file tree like WalkParallel and calls fn for every entry that is not a
+// directory (regular files, but also symlinks, which are not followed, and other special files),
+// while never running more than maxOpen calls of fn at the same time, so a callback that opens
+// the file (e.g. to hash it) cannot exhaust file descriptors on huge trees.
+//
+// It is WalkParallel with maxOpen workers, named for the resource it bounds.
+//
+// Parameters:
+//   - maxOpen: The maximum number of concurrent calls of fn. Zero or a negative value defaults
+//     to runtime.GOMAXPROCS(0).
+//   - fn: The function called for each non-directory entry. It must be safe for concurrent use
+//     and should close any file it opens before returning.
+//
+// Returns:
+//   - error: The first error returned by fn or encountered while enumerating the tree.
+//
+// Example usage:
+//
+//	err := Path("/data").WalkBounded(64, func(file *FsPath, info fs.FileInfo) error {
+//	    _, err := file.GetMD5()
+//	    return err
+//	})
+func (p *FsPath) WalkBounded(maxOpen int, fn func(p *FsPath, info fs.FileInfo) error) error {
+	return p.WalkParallel(maxOpen, fn)
+}
+
+// IterDir calls fn for each entry of the directory represented by this FsPath,
+// reading the directory in batches instead of loading all entries at once.
+//
+// Parameters:
+//   - fn: The function called with an FsPath for each child. Returning an error stops the iteration.
+//
+// Returns:
+//   - error: An error wrapping ErrNotDirectory if the path is not a directory,
+//     the first error returned by fn, or any error raised while reading the directory.
+//
+// Example usage:
+//
+//	dir := Path("/var/log")
+//	err := dir.IterDir(func(child *FsPath) error {
+//	    fmt.Println(child.Name)
+//	    return nil
+//	})
+//
+// Note: Entries are yielded in the order returned by the file system, which is not sorted.
+// The special entries "." and ".." are never yielded.
+func (p *FsPath) IterDir(fn func(child *FsPath) error) error {
+	info, err := p.Stat()
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	dir, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	for {
+		entries, err := dir.Readdir(iterDirBatchSize)
+
+		for _, entry := range entries {
+			if fnErr := fn(p.withSameFs(filepath.Join(p.absPath, entry.Name()))); fnErr != nil {
+				return fnErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TreeOptions controls the output of Tree.
+type TreeOptions struct {
+	// MaxDepth limits how many levels below the root are shown. 0 means unlimited.
+	MaxDepth int
+	// ShowHidden includes entries whose name starts with a dot.
+	ShowHidden bool
+	// DirsOnly omits files from the output.
+	DirsOnly bool
+}
+
+// Tree renders the directory represented by this FsPath as an ASCII tree,
+// like the `tree` command.
+//
+// The first line is the directory name, followed by one line per entry using
+// "├── ", "└── " and "│   " connectors. Entries are sorted by name within each directory.
+//
+// Parameters:
+//   - opts: The TreeOptions controlling depth, hidden entries and whether files are shown.
+//
+// Returns:
+//   - string: The rendered tree, each line terminated by a newline.
+//   - error: An error wrapping ErrNotDirectory if the path is not a directory,
+//     or any error raised while reading a directory.
+//
+// Example usage:
+//
+//	out, err := Path("/tmp/project").Tree(TreeOptions{MaxDepth: 2})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Print(out)
+//	// project
+//	// ├── cmd
+//	// │   └── main.go
+//	// └── go.mod
+func (p *FsPath) Tree(opts TreeOptions) (string, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(p.Name)
+	builder.WriteString("\n")
+
+	if err := p.writeTree(&builder, p.absPath, "", 1, opts); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func (p *FsPath) writeTree(builder *strings.Builder, dir, prefix string, depth int, opts TreeOptions) error {
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return nil
+	}
+
+	entries, err := afero.ReadDir(p.fs, dir)
+	if err != nil {
+		return err
+	}
+
+	visible := entries[:0]
+
+	for _, entry := range entries {
+		if !opts.ShowHidden && isHidden(filepath.Join(dir, entry.Name()), entry.Name()) {
+			continue
+		}
+
+		if opts.DirsOnly && !entry.IsDir() {
+			continue
+		}
+
+		visible = append(visible, entry)
+	}
+
+	for i, entry := range visible {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(visible)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+
+		builder.WriteString(prefix + connector + entry.Name() + "\n")
+
+		if entry.IsDir() {
+			err := p.writeTree(builder, filepath.Join(dir, entry.Name()), prefix+childPrefix, depth+1, opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
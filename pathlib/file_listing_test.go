@@ -2,8 +2,15 @@ package pathlib
 
 import (
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/afero"
 )
 
 func (s *PathSuite) TestListFilesWithGlobStatic() {
@@ -60,6 +67,38 @@ func (s *PathSuite) TestListFilesWithGlob() {
 	}
 }
 
+func (s *PathSuite) TestResolve() {
+	s.createTempFile("file1.txt", "")
+	s.createTempFile("file2.txt", "")
+	s.createTempFile("file3.json", "")
+
+	base := Path(s.tempDir)
+
+	matches, err := base.Resolve("*.txt")
+	s.Require().NoError(err)
+	s.Equal([]string{"file1.txt", "file2.txt"}, names(matches))
+
+	matches, err = Path("/").Resolve(filepath.Join(s.tempDir, "*.json"))
+	s.Require().NoError(err)
+	s.Equal([]string{"file3.json"}, names(matches))
+
+	s.T().Setenv("RESOLVE_TEST_DIR", s.tempDir)
+	matches, err = Path("/").Resolve("$RESOLVE_TEST_DIR/file1.*")
+	s.Require().NoError(err)
+	s.Equal([]string{"file1.txt"}, names(matches))
+
+	memFs := afero.NewMemMapFs()
+	memBase := Path("/mem").WithFs(memFs)
+	s.Require().NoError(memBase.Join("a.txt").WithFs(memFs).Touch())
+	matches, err = memBase.Resolve("*.txt")
+	s.Require().NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal(memFs, matches[0].Fs())
+
+	_, err = base.Resolve("[")
+	s.ErrorIs(err, filepath.ErrBadPattern)
+}
+
 func (s *PathSuite) TestWalk() {
 	// Create a temporary directory structure for testing
 	tempDir := s.T().TempDir()
@@ -126,3 +165,272 @@ func (s *PathSuite) TestWalk() {
 	s.Require().Error(err)
 	s.Equal(errTest, err)
 }
+
+func (s *PathSuite) TestWalkWithProgress() {
+	rootPath := Path(s.T().TempDir())
+
+	for _, name := range []string{"a.txt", "b.txt", "sub/c.txt", "sub/d.txt"} {
+		s.Require().NoError(rootPath.Join(name).WriteText(name))
+	}
+
+	// 6 entries: ".", "a.txt", "b.txt", "sub", "sub/c.txt", "sub/d.txt"
+	var (
+		visited  []string
+		progress []int
+	)
+
+	err := rootPath.WalkWithProgress(func(path string, info fs.FileInfo, err error) error {
+		visited = append(visited, path)
+		return err
+	}, func(n int) {
+		progress = append(progress, n)
+	}, 2)
+	s.Require().NoError(err)
+
+	s.Len(visited, 6)
+	s.Equal([]int{2, 4, 6}, progress)
+
+	// a non-multiple interval reports the final count once more
+	progress = nil
+	err = rootPath.WalkWithProgress(func(string, fs.FileInfo, error) error { return nil }, func(n int) {
+		progress = append(progress, n)
+	}, 4)
+	s.Require().NoError(err)
+	s.Equal([]int{4, 6}, progress)
+
+	// errors from walkFn are propagated unchanged
+	err = rootPath.WalkWithProgress(func(path string, _ fs.FileInfo, _ error) error {
+		if path == "sub" {
+			return errTest
+		}
+		return nil
+	}, nil)
+	s.Equal(errTest, err)
+}
+
+func (s *PathSuite) TestWalkParallel() {
+	rootPath := Path(s.T().TempDir())
+
+	const total = 50
+
+	for i := 0; i < total; i++ {
+		s.Require().NoError(rootPath.Join("sub"+strconv.Itoa(i%5), strconv.Itoa(i)+".txt").WriteText("x"))
+	}
+
+	var (
+		mu     sync.Mutex
+		counts = map[string]int{}
+	)
+
+	err := rootPath.WalkParallel(4, func(p *FsPath, info fs.FileInfo) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		s.False(info.IsDir())
+		counts[p.String()]++
+
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Len(counts, total)
+
+	for path, n := range counts {
+		s.Equal(1, n, path)
+	}
+
+	// an error from one worker aborts the rest
+	var visited atomic.Int32
+
+	err = rootPath.WalkParallel(2, func(*FsPath, fs.FileInfo) error {
+		if visited.Add(1) == 1 {
+			return errTest
+		}
+
+		return nil
+	})
+	s.Equal(errTest, err)
+	s.Less(int(visited.Load()), total)
+}
+
+func (s *PathSuite) TestWalkBounded() {
+	root := Path(s.tempDir).Join("bounded")
+	for i := 0; i < 40; i++ {
+		s.Require().NoError(root.Join("file" + strconv.Itoa(i) + ".txt").WriteText(_testContent))
+	}
+
+	// fixed limits, so the bound does not depend on the number of processors
+	for _, limit := range []int{2, 12} {
+		var (
+			open    atomic.Int32
+			maxOpen atomic.Int32
+			visited atomic.Int32
+		)
+
+		err := root.WalkBounded(limit, func(file *FsPath, _ fs.FileInfo) error {
+			current := open.Add(1)
+			defer open.Add(-1)
+
+			for {
+				peak := maxOpen.Load()
+				if current <= peak || maxOpen.CompareAndSwap(peak, current) {
+					break
+				}
+			}
+
+			f, err := file.Fs().Open(file.String())
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			// hold the file long enough for the other calls to pile up
+			time.Sleep(10 * time.Millisecond)
+			visited.Add(1)
+
+			return nil
+		})
+		s.Require().NoError(err)
+		s.Equal(int32(40), visited.Load())
+		s.Equal(int32(min(limit, 40)), maxOpen.Load(), "open handles reach the bound but never exceed it")
+	}
+}
+
+func (s *PathSuite) TestIterDir() {
+	rootPath := Path(s.T().TempDir())
+
+	const total = 300
+
+	expected := make([]string, 0, total+1)
+
+	for i := 0; i < total; i++ {
+		name := strconv.Itoa(i) + ".txt"
+		s.Require().NoError(rootPath.Join(name).Touch())
+		expected = append(expected, name)
+	}
+
+	s.Require().NoError(rootPath.Join("subdir").Mkdirs())
+	expected = append(expected, "subdir")
+
+	var names []string
+
+	err := rootPath.IterDir(func(child *FsPath) error {
+		names = append(names, child.Name)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.ElementsMatch(expected, names)
+
+	// early termination
+	visited := 0
+	err = rootPath.IterDir(func(*FsPath) error {
+		visited++
+		if visited == 3 {
+			return errTest
+		}
+
+		return nil
+	})
+	s.Equal(errTest, err)
+	s.Equal(3, visited)
+
+	// a file is not iterable
+	err = rootPath.Join("0.txt").IterDir(func(*FsPath) error { return nil })
+	s.ErrorIs(err, ErrNotDirectory)
+}
+
+func (s *PathSuite) TestTree() {
+	root := Path(s.tempDir).Join("project")
+	for _, name := range []string{"go.mod", "cmd/app/main.go", "internal/util.go", ".git/HEAD"} {
+		file := root.Join(name)
+		s.Require().NoError(file.MkParentDir())
+		s.Require().NoError(file.WriteText(""))
+	}
+
+	out, err := root.Tree(TreeOptions{})
+	s.Require().NoError(err)
+	s.Equal(`project
+├── cmd
+│   └── app
+│       └── main.go
+├── go.mod
+└── internal
+    └── util.go
+`, out)
+
+	out, err = root.Tree(TreeOptions{MaxDepth: 1, ShowHidden: true, DirsOnly: true})
+	s.Require().NoError(err)
+	s.Equal(`project
+├── .git
+├── cmd
+└── internal
+`, out)
+
+	_, err = root.Join("go.mod").Tree(TreeOptions{})
+	s.Require().ErrorIs(err, ErrNotDirectory)
+}
+
+// unreadableDirFs fails to open the given directory, like a directory without read permission.
+type unreadableDirFs struct {
+	afero.Fs
+	denied string
+}
+
+func (f unreadableDirFs) Open(name string) (afero.File, error) {
+	if name == f.denied {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+	}
+
+	return f.Fs.Open(name)
+}
+
+func (s *PathSuite) TestWalkContinue() {
+	mem := afero.NewMemMapFs()
+	for _, name := range []string{"/root/a.txt", "/root/locked/secret.txt", "/root/open/b.txt"} {
+		s.Require().NoError(afero.WriteFile(mem, name, []byte("x"), 0o644))
+	}
+
+	root := Path("/root").WithFs(unreadableDirFs{Fs: mem, denied: "/root/locked"})
+
+	var visited []string
+
+	errs := root.WalkContinue(func(p *FsPath, info fs.FileInfo, err error) {
+		if err == nil {
+			visited = append(visited, p.String())
+		}
+	})
+
+	s.Equal([]string{"/root", "/root/a.txt", "/root/locked", "/root/open", "/root/open/b.txt"}, visited)
+	s.Require().Len(errs, 1)
+	s.ErrorIs(errs[0], os.ErrPermission)
+
+	s.Len(Path("/missing").WithFs(mem).WalkContinue(func(*FsPath, fs.FileInfo, error) {}), 1)
+}
+
+func (s *PathSuite) TestListRelative() {
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.tempDir, "dist", "assets"), 0o755))
+
+	for _, name := range []string{"assets/vendor.js", "assets/app.js", "assets/app.css", "index.html"} {
+		s.createTempFile(filepath.Join("dist", name), "")
+	}
+
+	dist := filepath.Join(s.tempDir, "dist")
+	assets := Path(filepath.Join(dist, "assets"))
+
+	files, err := assets.ListRelative(dist, "*.js")
+	s.Require().NoError(err)
+	s.Equal([]string{filepath.Join("assets", "app.js"), filepath.Join("assets", "vendor.js")}, files)
+
+	files, err = assets.ListRelative(assets.String(), "")
+	s.Require().NoError(err)
+	s.Equal([]string{"app.css", "app.js", "vendor.js"}, files)
+
+	files, err = assets.ListRelative(dist, "*.md")
+	s.Require().NoError(err)
+	s.Empty(files)
+
+	_, err = Path(dist).ListRelative(assets.String(), "*")
+	s.ErrorIs(err, ErrIllegalFilePath)
+
+	_, err = assets.ListRelative(dist, "[")
+	s.ErrorIs(err, filepath.ErrBadPattern)
+}
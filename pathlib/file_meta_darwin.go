@@ -0,0 +1,18 @@
+package pathlib
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+)
+
+// fileMeta returns the access time and owner of the file described by info.
+// ok is false if the file system doesn't expose them.
+func fileMeta(info fs.FileInfo) (atime time.Time, uid, gid int, ok bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, 0, 0, false
+	}
+
+	return time.Unix(st.Atimespec.Sec, st.Atimespec.Nsec), int(st.Uid), int(st.Gid), true
+}
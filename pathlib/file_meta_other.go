@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package pathlib
+
+import (
+	"io/fs"
+	"time"
+)
+
+func fileMeta(fs.FileInfo) (atime time.Time, uid, gid int, ok bool) {
+	return time.Time{}, 0, 0, false
+}
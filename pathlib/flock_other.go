@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !windows
+
+package pathlib
+
+import (
+	"os"
+)
+
+func lockFile(*os.File, bool) error {
+	return ErrNotSupported
+}
+
+func unlockFile(*os.File) error {
+	return ErrNotSupported
+}
@@ -4,15 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
 	"time"
+
+	"github.com/spf13/afero"
 )
 
 var (
 	ErrNotDirectory      = errors.New("path is not a directory")
 	ErrDirectoryNotEmpty = errors.New("directory not empty")
 	ErrCannotUnlinkDir   = errors.New("cannot unlink directory: use Rmdir() instead")
+	ErrIsDirectory       = errors.New("path is a directory")
+	ErrDirNotSynced      = errors.New("file was replaced but its directory could not be synced")
 )
 
 // Copy creates a copy of the file at the current path to a new location.
@@ -46,12 +54,13 @@ var (
 //
 // Note: This method does not handle copying directories. It's designed for single file operations.
 func (p *FsPath) Copy(newfile string) error {
-	sourceFile, err := p.Reader()
+	sourceFile, err := p.fs.Open(p.absPath)
 	if err != nil {
 		return err
 	}
+	defer sourceFile.Close()
 
-	destFile, err := os.Create(newfile)
+	destFile, err := p.fs.Create(newfile)
 	if err != nil {
 		return err
 	}
@@ -64,29 +73,574 @@ func (p *FsPath) Copy(newfile string) error {
 
 	si, err := p.Stat()
 	if err == nil {
-		err = os.Chmod(newfile, si.Mode())
+		err = p.fs.Chmod(newfile, si.Mode())
 	}
 
-	return err
+	return auditResult(AuditWrite, newfile, err)
+}
+
+// CopyWithMeta copies the file to dest like Copy, and additionally gives the copy the access
+// and modification times of the source, so mtime-based incremental backups treat it as
+// unchanged. Copy itself keeps resetting the times.
+//
+// With preserveOwner set to true the owner and group are copied too, which usually requires
+// root privileges. The access time is only available on Linux and macOS; elsewhere, and on
+// file systems that don't expose it, the modification time is used for both.
+//
+// Parameters:
+//   - dest: The path of the copy.
+//   - preserveOwner: Optional; if true, the copy is chowned to the owner and group of the source.
+//
+// Returns:
+//   - error: An error if the copy fails or the times cannot be set, an error if the owner
+//     cannot be changed, or an error wrapping ErrNotSupported if preserveOwner is requested
+//     and the owner of the source is not available.
+//
+// Example:
+//
+//	err := Path("/data/report.csv").CopyWithMeta("/backup/report.csv")
+func (p *FsPath) CopyWithMeta(dest string, preserveOwner ...bool) error {
+	info, err := p.fs.Stat(p.absPath)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Copy(dest); err != nil {
+		return err
+	}
+
+	atime, uid, gid, ok := fileMeta(info)
+	if !ok {
+		atime = info.ModTime()
+	}
+
+	if len(preserveOwner) > 0 && preserveOwner[0] {
+		if !ok {
+			return fmt.Errorf("%w: cannot read the owner of %s", ErrNotSupported, p.absPath)
+		}
+
+		if err := p.fs.Chown(dest, uid, gid); err != nil {
+			return err
+		}
+
+		// chown clears the setuid and setgid bits, restore them
+		if err := auditResult(AuditChmod, dest, p.fs.Chmod(dest, info.Mode())); err != nil {
+			return err
+		}
+	}
+
+	return auditResult(AuditWrite, dest, p.fs.Chtimes(dest, atime, info.ModTime()))
+}
+
+// backupSuffix is appended to the file name by Backup.
+const backupSuffix = ".bak"
+
+// CopyThrottled copies the file to dest like Copy, but limits the transfer rate to bytesPerSec,
+// e.g. to keep a backup job from saturating the disk of a shared host.
+//
+// The source is read in chunks of at most a tenth of the limit, and the copy sleeps as needed
+// so the average rate since the start never exceeds bytesPerSec. The file mode is preserved.
+//
+// Parameters:
+//   - dest: The path of the copy.
+//   - bytesPerSec: The maximum transfer rate in bytes per second. Zero or a negative value
+//     means no limit.
+//
+// Returns:
+//   - int64: The number of bytes copied.
+//   - error: An error if the source cannot be read, or dest cannot be written.
+//
+// Example:
+//
+//	// copy at most 10 MiB per second
+//	_, err := Path("/data/dump.sql").CopyThrottled("/backup/dump.sql", 10<<20)
+func (p *FsPath) CopyThrottled(dest string, bytesPerSec int64) (int64, error) {
+	sourceFile, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return 0, err
+	}
+	defer sourceFile.Close()
+
+	destFile, err := p.fs.Create(dest)
+	if err != nil {
+		return 0, err
+	}
+	defer destFile.Close()
+
+	var src io.Reader = sourceFile
+	if bytesPerSec > 0 {
+		src = newThrottledReader(sourceFile, bytesPerSec)
+	}
+
+	// hide WriterTo/ReaderFrom so io.Copy goes through the throttled Read
+	written, err := io.Copy(struct{ io.Writer }{destFile}, struct{ io.Reader }{src})
+	if err != nil {
+		return written, err
+	}
+
+	si, err := p.Stat()
+	if err == nil {
+		err = p.fs.Chmod(dest, si.Mode())
+	}
+
+	return written, auditResult(AuditWrite, dest, err)
+}
+
+// throttledReader limits the average read rate of r to bytesPerSec.
+type throttledReader struct {
+	r           io.Reader
+	bytesPerSec int64
+	chunkSize   int
+	start       time.Time
+	read        int64
+}
+
+const maxThrottleChunk = 32 * 1024
+
+func newThrottledReader(r io.Reader, bytesPerSec int64) *throttledReader {
+	chunk := bytesPerSec / 10
+	chunk = max(1, min(chunk, maxThrottleChunk))
+
+	return &throttledReader{r: r, bytesPerSec: bytesPerSec, chunkSize: int(chunk), start: time.Now()}
+}
+
+func (t *throttledReader) Read(buf []byte) (int, error) {
+	if len(buf) > t.chunkSize {
+		buf = buf[:t.chunkSize]
+	}
+
+	n, err := t.r.Read(buf)
+	t.read += int64(n)
+
+	expected := time.Duration(float64(t.read) / float64(t.bytesPerSec) * float64(time.Second))
+	if wait := expected - time.Since(t.start); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	return n, err
+}
+
+// CopyInto copies the file into destDir, keeping its name, and returns the copy.
+// destDir is created if it doesn't exist, and the file mode is preserved like Copy does.
+//
+// Example:
+//
+//	copied, err := Path("/data/report.pdf").CopyInto("/backup/2024")
+//	// copied is "/backup/2024/report.pdf"
+func (p *FsPath) CopyInto(destDir string) (*FsPath, error) {
+	dest := p.withSameFs(filepath.Join(destDir, p.Name))
+	if err := dest.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Copy(dest.absPath); err != nil {
+		return nil, err
+	}
+
+	return dest, nil
+}
+
+// MoveInto moves the file or directory into destDir, keeping its name, and returns the new path.
+// destDir is created if it doesn't exist. Like Move, it falls back to copy and delete when
+// destDir is on another device.
+//
+// Example:
+//
+//	moved, err := Path("/incoming/report.pdf").MoveInto("/archive")
+//	// moved is "/archive/report.pdf"
+func (p *FsPath) MoveInto(destDir string) (*FsPath, error) {
+	dest := p.withSameFs(filepath.Join(destDir, p.Name))
+	if err := dest.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Move(dest.absPath); err != nil {
+		return nil, err
+	}
+
+	p.Invalidate()
+
+	return dest, nil
+}
+
+// Backup copies the file to a sibling backup before it gets modified or deleted.
+//
+// The backup is named after the file with a ".bak" suffix, e.g. "config.yaml.bak", and an
+// existing backup is overwritten. If timestamped is true, the current time is appended as well,
+// e.g. "config.yaml.bak.20060102-150405", so successive backups are kept.
+//
+// Parameters:
+//   - timestamped: Optional; whether to add a timestamp to the backup name. Defaults to false.
+//
+// Returns:
+//   - *FsPath: The backup file.
+//   - error: An error if the file doesn't exist or cannot be copied.
+//
+// Example:
+//
+//	file := Path("/etc/app/config.yaml")
+//	if _, err := file.Backup(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	err := file.WriteText(newConfig)
+func (p *FsPath) Backup(timestamped ...bool) (*FsPath, error) {
+	if _, err := p.Stat(); err != nil {
+		return nil, err
+	}
+
+	name := p.absPath + backupSuffix
+	if len(timestamped) > 0 && timestamped[0] {
+		name += "." + time.Now().Format("20060102-150405")
+	}
+
+	backup := p.withSameFs(name)
+	if err := p.Copy(backup.absPath); err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// RestoreBackup copies the ".bak" sibling created by Backup back over the file.
+// Timestamped backups are not considered; restore those with Copy instead.
+//
+// Returns:
+//   - error: An error if the backup doesn't exist or cannot be copied.
+func (p *FsPath) RestoreBackup() error {
+	backup := p.withSameFs(p.absPath + backupSuffix)
+	if _, err := backup.Stat(); err != nil {
+		return err
+	}
+
+	p.Invalidate()
+
+	return backup.Copy(p.absPath)
+}
+
+// CopyDirOptions configures CopyDir.
+type CopyDirOptions struct {
+	// RewriteInternalSymlinks rewrites absolute symlinks whose targets are inside the source
+	// directory so that they point to the same entry inside the destination.
+	RewriteInternalSymlinks bool
 }
 
-// Mkdirs quick create dir for given path with MkdirAll.
+// CopyDirOption is a function type for setting CopyDir options.
+type CopyDirOption func(*CopyDirOptions)
+
+// RewriteInternalSymlinks sets whether CopyDir rewrites absolute symlinks pointing into the
+// source tree, keeping a self-contained tree self-contained after copying.
+func RewriteInternalSymlinks(rewrite bool) CopyDirOption {
+	return func(o *CopyDirOptions) {
+		o.RewriteInternalSymlinks = rewrite
+	}
+}
+
+// CopyDir recursively copies the directory at the current path to dest.
+//
+// Directories are recreated with their original permissions and files are copied with Copy,
+// so file modes are preserved as well. Existing files in dest are overwritten.
+//
+// Symlinks are recreated as symlinks when the file system supports them: relative links and
+// links pointing outside the source directory are kept as-is, while absolute links into the
+// source directory are rewritten to the destination if RewriteInternalSymlinks is set.
+//
+// Parameters:
+//   - dest: The path of the destination directory. It is created if it doesn't exist.
+//   - opts: Optional CopyDirOption functions, e.g. RewriteInternalSymlinks(true).
+//
+// Returns:
+//   - error: An error wrapping ErrNotDirectory if the current path is not a directory,
+//     or any error encountered while walking or copying.
+//
+// Example:
+//
+//	err := Path("/data/project").CopyDir("/backup/project", RewriteInternalSymlinks(true))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) CopyDir(dest string, opts ...CopyDirOption) error {
+	if !p.IsDir() {
+		return fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	options := CopyDirOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	linker, canLink := p.fs.(afero.Linker)
+	reader, canRead := p.fs.(afero.LinkReader)
+
+	return p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, relPath)
+
+		if info.IsDir() {
+			return p.fs.MkdirAll(target, info.Mode().Perm())
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 && canLink && canRead {
+			link, err := reader.ReadlinkIfPossible(filepath.Join(p.absPath, relPath))
+			if err != nil {
+				return err
+			}
+
+			if options.RewriteInternalSymlinks {
+				link = p.rebaseLink(link, dest)
+			}
+
+			if err := auditResult(AuditDelete, target, p.fs.Remove(target)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			return auditResult(AuditWrite, target, linker.SymlinkIfPossible(link, target))
+		}
+
+		return p.withSameFs(filepath.Join(p.absPath, relPath)).Copy(target)
+	})
+}
+
+// rebaseLink returns link rewritten into dest if it is an absolute path inside the current
+// directory, and link unchanged otherwise.
+func (p *FsPath) rebaseLink(link, dest string) string {
+	if !filepath.IsAbs(link) {
+		return link
+	}
+
+	rel, err := filepath.Rel(p.absPath, filepath.Clean(link))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return link
+	}
+
+	return filepath.Join(Path(dest).absPath, rel)
+}
+
+// RmTree removes the path and any children it contains, like `rm -rf`.
+// It returns nil if the path does not exist.
+func (p *FsPath) RmTree() error {
+	return auditResult(AuditDelete, p.absPath, p.fs.RemoveAll(p.absPath))
+}
+
+// Mkdirs quick create dir for given path with MkdirAll, using DefaultDirMode.
 func (p *FsPath) Mkdirs() error {
-	return p.fs.MkdirAll(p.absPath, DirMode755)
+	return p.fs.MkdirAll(p.absPath, DefaultDirMode)
 }
 
-// MkParentDir creates the parent directory for the given path
+// MkParentDir creates the parent directory for the given path, using DefaultDirMode.
 func (p *FsPath) MkParentDir() error {
-	return p.fs.MkdirAll(filepath.Dir(p.absPath), DirMode755)
+	return p.MkParentDirMode(DefaultDirMode)
+}
+
+// MkParentDirMode creates the parent directory for the given path with the given permissions.
+// Any missing ancestors are created with the same permissions (before umask).
+//
+// Example:
+//
+//	err := Path("/srv/private/data/file.txt").MkParentDirMode(0o700)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) MkParentDirMode(perm os.FileMode) error {
+	return p.fs.MkdirAll(filepath.Dir(p.absPath), perm)
+}
+
+// EnsureDir creates the directory for the given path with MkdirAll and returns the receiver,
+// so it can be chained with other calls.
+//
+// Example:
+//
+//	dir, err := Path("/tmp/output").EnsureDir()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = dir.Join("result.txt").WriteText("done")
+func (p *FsPath) EnsureDir() (*FsPath, error) {
+	if err := p.Mkdirs(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
 }
 
+// EnsureParentDir creates the parent directory for the given path and returns the receiver,
+// so it can be chained with other calls.
+//
+// Example:
+//
+//	file, err := Path("/tmp/output/result.txt").EnsureParentDir()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = file.Touch()
+func (p *FsPath) EnsureParentDir() (*FsPath, error) {
+	if err := p.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Move moves the file or directory to newfile.
+//
+// It first tries Rename. If that fails because source and destination are on different
+// devices (EXDEV, e.g. across Docker volume mounts), it falls back to copying the data
+// and removing the source, like `mv` does: Copy then Unlink for files, CopyDir then RmTree for directories.
+//
+// Note: The fallback is not atomic. If it fails midway, the destination may be
+// partially written while the source still exists.
 func (p *FsPath) Move(newfile string) error {
-	return p.Rename(newfile)
+	err := p.Rename(newfile)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	info, err := p.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		if err := p.CopyDir(newfile); err != nil {
+			return err
+		}
+
+		return p.RmTree()
+	}
+
+	if err := p.Copy(newfile); err != nil {
+		return err
+	}
+
+	return p.Unlink(false)
+}
+
+// SwapWith atomically exchanges the current path with other, e.g. to flip an active and
+// a standby config file. Both paths must exist; they may be files or directories.
+//
+// On Linux with the OS file system, the exchange uses renameat2 with RENAME_EXCHANGE, so there is
+// no moment where either path is missing. Elsewhere, or if the file system doesn't support it,
+// it falls back to three renames through a temporary name in the same directory, during which
+// the current path briefly doesn't exist.
+//
+// Returns:
+//   - error: An error if either path doesn't exist or a rename fails. If the fallback fails
+//     midway, it tries to restore the original names.
+//
+// Example:
+//
+//	active := Path("/etc/app/active.yaml")
+//	if err := active.SwapWith(Path("/etc/app/standby.yaml")); err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) SwapWith(other *FsPath) error {
+	if _, err := p.Stat(); err != nil {
+		return err
+	}
+
+	if _, err := other.Stat(); err != nil {
+		return err
+	}
+
+	defer p.Invalidate()
+	defer other.Invalidate()
+
+	if _, ok := p.fs.(*afero.OsFs); ok {
+		err := renameExchange(p.absPath, other.absPath)
+		if err == nil {
+			p.auditSwap(other)
+			return nil
+		}
+
+		if !isExchangeUnsupported(err) {
+			return err
+		}
+	}
+
+	tmp := p.withSameFs(filepath.Join(filepath.Dir(p.absPath),
+		fmt.Sprintf(".%s.swap-%d", p.Name, time.Now().UnixNano())))
+
+	if err := p.fs.Rename(p.absPath, tmp.absPath); err != nil {
+		return err
+	}
+
+	if err := p.fs.Rename(other.absPath, p.absPath); err != nil {
+		_ = p.fs.Rename(tmp.absPath, p.absPath)
+		return err
+	}
+
+	if err := p.fs.Rename(tmp.absPath, other.absPath); err != nil {
+		_ = p.fs.Rename(p.absPath, other.absPath)
+		_ = p.fs.Rename(tmp.absPath, p.absPath)
+
+		return err
+	}
+
+	p.auditSwap(other)
+
+	return nil
+}
+
+// auditSwap reports a completed SwapWith as a rename of each of the two paths.
+func (p *FsPath) auditSwap(other *FsPath) {
+	audit(AuditRename, p.absPath)
+	audit(AuditRename, other.absPath)
+}
+
+// isExchangeUnsupported reports whether renameExchange failed because the platform or
+// the file system doesn't support atomic exchange.
+func isExchangeUnsupported(err error) bool {
+	return errors.Is(err, ErrNotSupported) || errors.Is(err, syscall.ENOSYS) ||
+		errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTSUP)
+}
+
+// RelocateWithSuffix moves the file into a renamed sibling of its parent directory and changes
+// its suffix in one go, e.g. "in/x.txt" becomes "out/x.json". The new location is computed
+// with WithReplacedDirAndSuffix, its directory is created if needed and the file is moved
+// there with Move.
+//
+// Parameters:
+//   - dirName: The name of the directory replacing the current parent directory.
+//   - newSuffix: The new file suffix, with or without the leading dot.
+//
+// Returns:
+//   - *FsPath: The relocated file.
+//   - error: An error wrapping ErrIsDirectory if the path is a directory, or an error if the
+//     file doesn't exist or cannot be moved.
+//
+// Example:
+//
+//	out, err := Path("/data/in/report.txt").RelocateWithSuffix("out", ".json")
+//	// out is "/data/out/report.json"
+func (p *FsPath) RelocateWithSuffix(dirName, newSuffix string) (*FsPath, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrIsDirectory, p.absPath)
+	}
+
+	dest := p.withSameFs(p.WithReplacedDirAndSuffix(dirName, newSuffix).absPath)
+	if err := dest.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Move(dest.absPath); err != nil {
+		return nil, err
+	}
+
+	p.Invalidate()
+
+	return dest, nil
 }
 
 // Rename moves the file to a new location
 func (p *FsPath) Rename(newfile string) error {
-	return p.fs.Rename(p.absPath, newfile)
+	return auditResult(AuditRename, p.absPath, p.fs.Rename(p.absPath, newfile))
 }
 
 // Mkdir creates a new directory with the specified permissions.
@@ -153,7 +707,7 @@ func (p *FsPath) Touch() error {
 
 	currentTime := time.Now().Local()
 
-	return p.fs.Chtimes(p.absPath, currentTime, currentTime)
+	return auditResult(AuditWrite, p.absPath, p.fs.Chtimes(p.absPath, currentTime, currentTime))
 }
 
 // Chmod changes the mode of the file to the given mode.
@@ -171,7 +725,59 @@ func (p *FsPath) Touch() error {
 //	    log.Fatal(err)
 //	}
 func (p *FsPath) Chmod(mode os.FileMode) error {
-	return p.fs.Chmod(p.absPath, mode)
+	return auditResult(AuditChmod, p.absPath, p.fs.Chmod(p.absPath, mode))
+}
+
+// ChmodRecursive normalizes the permissions of the tree rooted at the path, e.g. after
+// extracting an archive: directories, including the root, get dirMode and regular files
+// get fileMode. Symbolic links and other special files are left alone, and symlinked
+// directories are not followed.
+//
+// Directory modes are applied after their content has been processed, deepest first, so a
+// dirMode that denies reading or searching doesn't lock the walk out of the tree.
+//
+// Parameters:
+//   - fileMode: The mode for regular files.
+//   - dirMode: The mode for directories.
+//
+// Returns:
+//   - error: The first error encountered while walking the tree or changing a mode.
+//
+// Example:
+//
+//	err := Path("/srv/extracted").ChmodRecursive(0o644, 0o755)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) ChmodRecursive(fileMode, dirMode os.FileMode) error {
+	var dirs []string
+
+	err := afero.Walk(p.fs, p.absPath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case info.IsDir():
+			dirs = append(dirs, path)
+		case info.Mode().IsRegular():
+			return auditResult(AuditChmod, path, p.fs.Chmod(path, fileMode))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// walk order is pre-order, so reversed it lists children before their parents
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := auditResult(AuditChmod, dirs[i], p.fs.Chmod(dirs[i], dirMode)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Unlink removes the file or symbolic link pointed to by the path.
@@ -216,7 +822,7 @@ func (p *FsPath) Unlink(missingOK bool) error {
 		return fmt.Errorf("%w: %s", ErrCannotUnlinkDir, p.absPath)
 	}
 
-	return p.fs.Remove(p.absPath)
+	return auditResult(AuditDelete, p.absPath, p.fs.Remove(p.absPath))
 }
 
 // Rmdir removes the empty directory pointed to by the path.
@@ -276,5 +882,108 @@ func (p *FsPath) Rmdir() error {
 	}
 
 	// Directory is empty, remove it
-	return p.fs.Remove(p.absPath)
+	return auditResult(AuditDelete, p.absPath, p.fs.Remove(p.absPath))
+}
+
+// Sync commits the current contents of the file to stable storage (fsync).
+//
+// Returns:
+//   - error: An error if the file cannot be opened or synced.
+//
+// Note: This only has an effect on the OS file system; on in-memory file systems
+// such as afero.MemMapFs, syncing is a no-op that returns nil.
+func (p *FsPath) Sync() error {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
+
+// SyncDir commits the parent directory of the path to stable storage, so that a newly
+// created or renamed entry survives a power loss. Call it after Rename or file creation
+// when durability matters.
+//
+// Note: Like Sync, this is a no-op on in-memory file systems. It is also a no-op on Windows,
+// which does not support syncing directories.
+func (p *FsPath) SyncDir() error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	return p.withSameFs(filepath.Dir(p.absPath)).Sync()
+}
+
+// syncDirAfterRename runs SyncDir once a rename has already replaced the file. A failure is
+// wrapped in ErrDirNotSynced, so callers can tell that the new content is in place and only
+// its durability across a power loss is in doubt.
+func (p *FsPath) syncDirAfterRename() error {
+	if err := p.SyncDir(); err != nil {
+		return fmt.Errorf("%w: %w", ErrDirNotSynced, err)
+	}
+
+	return nil
+}
+
+// SyncTree commits every file below the directory, and the directories themselves, to stable
+// storage, so that a bulk extraction or generation survives a crash with a single call.
+//
+// Files are synced as they are walked and directories afterwards, deepest first, so that each
+// directory entry is flushed after the files it refers to. Symlinks are not followed.
+//
+// Returns:
+//   - error: An error wrapping ErrNotDirectory if the path is not a directory,
+//     or the first error encountered while walking or syncing.
+//
+// Note: Like Sync, this is a no-op on in-memory file systems, and directories are not synced
+// on Windows. File systems that reject fsync with EINVAL or ENOTSUP are skipped silently.
+func (p *FsPath) SyncTree() error {
+	if !p.IsDir() {
+		return fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	var dirs []*FsPath
+
+	err := p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		entry := p.withSameFs(filepath.Join(p.absPath, relPath))
+
+		switch {
+		case info.IsDir():
+			dirs = append(dirs, entry)
+		case info.Mode().IsRegular():
+			return ignoreSyncUnsupported(entry.Sync())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := ignoreSyncUnsupported(dirs[i].Sync()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ignoreSyncUnsupported drops the errors returned by file systems that don't support fsync.
+func ignoreSyncUnsupported(err error) error {
+	if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTSUP) {
+		return nil
+	}
+
+	return err
 }
@@ -0,0 +1,372 @@
+package pathlib
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func (s *PathSuite) TestEnsureDir() {
+	dir := Path(s.tempDir).Join("a", "b", "c")
+
+	got, err := dir.EnsureDir()
+	s.Require().NoError(err)
+	s.Same(dir, got)
+	s.DirExists(dir.String())
+}
+
+func (s *PathSuite) TestEnsureParentDir() {
+	file := Path(s.tempDir).Join("x", "y", "file.txt")
+
+	got, err := file.EnsureParentDir()
+	s.Require().NoError(err)
+	s.Same(file, got)
+	s.DirExists(file.Parent().String())
+	s.NoFileExists(file.String())
+}
+
+func (s *PathSuite) TestMkParentDirMode() {
+	file := Path(s.tempDir).Join("private", "nested", "file.txt")
+
+	err := file.MkParentDirMode(0o700)
+	s.Require().NoError(err)
+
+	for _, dir := range []*FsPath{file.Parent(), file.Parent().Parent()} {
+		info, err := dir.Stat()
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(0o700), info.Mode().Perm(), dir.String())
+	}
+}
+
+func (s *PathSuite) TestDefaultDirMode() {
+	original := DefaultDirMode
+	DefaultDirMode = 0o700
+
+	defer func() {
+		DefaultDirMode = original
+	}()
+
+	file := Path(s.tempDir).Join("implicit", "file.txt")
+	s.Require().NoError(file.WriteText(_testContent))
+
+	info, err := file.Parent().Stat()
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o700), info.Mode().Perm())
+}
+
+// crossDeviceFs is an afero.Fs whose Rename always fails as if crossing devices.
+type crossDeviceFs struct {
+	afero.Fs
+}
+
+func (c crossDeviceFs) Rename(oldname, newname string) error {
+	return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: syscall.EXDEV}
+}
+
+func (s *PathSuite) TestMoveCrossDevice() {
+	srcPath := s.createTempFile("src.txt", _testContent)
+	dstPath := filepath.Join(s.tempDir, "dst.txt")
+
+	file := Path(srcPath)
+	file.fs = crossDeviceFs{afero.NewOsFs()}
+
+	s.Require().NoError(file.Move(dstPath))
+	s.NoFileExists(srcPath)
+
+	content, err := os.ReadFile(dstPath)
+	s.Require().NoError(err)
+	s.Equal(_testContent, string(content))
+}
+
+func (s *PathSuite) TestMoveCrossDeviceDir() {
+	srcDir := Path(s.tempDir).Join("srcdir")
+	s.Require().NoError(srcDir.Join("a.txt").WriteText("a"))
+	s.Require().NoError(srcDir.Join("sub", "b.txt").WriteText("b"))
+
+	dstDir := filepath.Join(s.tempDir, "dstdir")
+
+	srcDir.fs = crossDeviceFs{afero.NewOsFs()}
+
+	s.Require().NoError(srcDir.Move(dstDir))
+	s.NoDirExists(srcDir.String())
+
+	s.Equal("a", Path(dstDir).Join("a.txt").MustReadText())
+	s.Equal("b", Path(dstDir).Join("sub", "b.txt").MustReadText())
+}
+
+func (s *PathSuite) TestCopyDir() {
+	srcDir := Path(s.tempDir).Join("src")
+	s.Require().NoError(srcDir.Join("a.txt").WriteText("a"))
+	s.Require().NoError(srcDir.Join("empty").Mkdirs())
+
+	dstDir := Path(s.tempDir).Join("dst")
+	s.Require().NoError(srcDir.CopyDir(dstDir.String()))
+
+	s.Equal("a", dstDir.Join("a.txt").MustReadText())
+	s.DirExists(dstDir.Join("empty").String())
+
+	err := srcDir.Join("a.txt").CopyDir(dstDir.String())
+	s.ErrorIs(err, ErrNotDirectory)
+}
+
+func (s *PathSuite) TestCopyDirSymlinks() {
+	srcDir := Path(s.tempDir).Join("src")
+	s.Require().NoError(srcDir.Join("data", "a.txt").WriteText("a"))
+
+	external := s.createTempFile("external.txt", "external")
+	internal := srcDir.Join("data", "a.txt").String()
+
+	s.Require().NoError(os.Symlink(internal, srcDir.Join("internal").String()))
+	s.Require().NoError(os.Symlink(filepath.Join("data", "a.txt"), srcDir.Join("relative").String()))
+	s.Require().NoError(os.Symlink(external, srcDir.Join("external").String()))
+
+	keptDir := Path(s.tempDir).Join("kept")
+	s.Require().NoError(srcDir.CopyDir(keptDir.String()))
+
+	link, err := os.Readlink(keptDir.Join("internal").String())
+	s.Require().NoError(err)
+	s.Equal(internal, link)
+
+	rewrittenDir := Path(s.tempDir).Join("rewritten")
+	s.Require().NoError(srcDir.CopyDir(rewrittenDir.String(), RewriteInternalSymlinks(true)))
+
+	link, err = os.Readlink(rewrittenDir.Join("internal").String())
+	s.Require().NoError(err)
+	s.Equal(rewrittenDir.Join("data", "a.txt").String(), link)
+
+	for _, dir := range []*FsPath{keptDir, rewrittenDir} {
+		link, err = os.Readlink(dir.Join("relative").String())
+		s.Require().NoError(err)
+		s.Equal(filepath.Join("data", "a.txt"), link)
+
+		link, err = os.Readlink(dir.Join("external").String())
+		s.Require().NoError(err)
+		s.Equal(external, link)
+
+		s.Equal("a", dir.Join("relative").MustReadText())
+	}
+}
+
+func (s *PathSuite) TestSync() {
+	file := Path(s.tempDir).Join("durable.txt")
+	s.Require().NoError(file.WriteText(_testContent))
+
+	s.NoError(file.Sync())
+	s.NoError(file.SyncDir())
+
+	s.Error(Path(s.tempDir).Join("missing.txt").Sync())
+
+	memFile := Path("/mem/durable.txt")
+	memFile.fs = afero.NewMemMapFs()
+	s.Require().NoError(memFile.WriteText(_testContent))
+	s.NoError(memFile.Sync())
+	s.NoError(memFile.SyncDir())
+}
+
+// unsyncableDirFs is an afero.Fs that refuses to open directories, so SyncDir always fails.
+type unsyncableDirFs struct {
+	afero.Fs
+}
+
+func (u unsyncableDirFs) Open(name string) (afero.File, error) {
+	if info, err := u.Fs.Stat(name); err == nil && info.IsDir() {
+		return nil, &os.PathError{Op: "open", Path: name, Err: syscall.EACCES}
+	}
+
+	return u.Fs.Open(name)
+}
+
+func (s *PathSuite) TestSyncDirAfterRename() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("SyncDir is a no-op on Windows")
+	}
+
+	file := Path(s.createTempFile("state.json", `{"offset":1}`))
+	file.fs = unsyncableDirFs{afero.NewOsFs()}
+
+	// the rename has already happened, so the new content is in place despite the error
+	err := file.WriteJSONAtomic(map[string]int{"offset": 2})
+	s.Require().ErrorIs(err, ErrDirNotSynced)
+	s.Require().ErrorIs(err, syscall.EACCES)
+	s.Equal("{\n  \"offset\": 2\n}", file.MustReadText())
+
+	err = file.EditAtomic(func(tmp *FsPath) error {
+		return tmp.WriteText("edited")
+	})
+	s.Require().ErrorIs(err, ErrDirNotSynced)
+	s.Equal("edited", file.MustReadText())
+}
+
+func (s *PathSuite) TestSyncTree() {
+	dir := Path(s.tempDir).Join("tree")
+	s.Require().NoError(dir.Join("a.txt").WriteText("a"))
+	s.Require().NoError(dir.Join("sub", "deeper", "b.txt").WriteText("b"))
+	s.Require().NoError(dir.Join("empty").Mkdirs())
+
+	s.NoError(dir.SyncTree())
+	s.ErrorIs(dir.Join("a.txt").SyncTree(), ErrNotDirectory)
+
+	memDir := Path("/mem/tree")
+	memDir.fs = afero.NewMemMapFs()
+	s.Require().NoError(memDir.Join("a.txt").WithFs(memDir.fs).WriteText("a"))
+	s.NoError(memDir.SyncTree())
+}
+
+func (s *PathSuite) TestBackup() {
+	file := Path(s.createTempFile("config.yaml", _testContent))
+
+	backup, err := file.Backup()
+	s.Require().NoError(err)
+	s.Equal(file.String()+".bak", backup.String())
+	s.Equal(_testContent, backup.MustReadText())
+
+	s.Require().NoError(file.WriteText("modified"))
+	s.Require().NoError(file.RestoreBackup())
+	s.Equal(_testContent, file.MustReadText())
+
+	stamped, err := file.Backup(true)
+	s.Require().NoError(err)
+	s.Regexp(`config\.yaml\.bak\.\d{8}-\d{6}$`, stamped.String())
+	s.FileExists(stamped.String())
+
+	missing := Path(s.tempDir).Join("missing.txt")
+	_, err = missing.Backup()
+	s.ErrorIs(err, os.ErrNotExist)
+	s.ErrorIs(missing.RestoreBackup(), os.ErrNotExist)
+}
+
+func (s *PathSuite) TestRelocateWithSuffix() {
+	src := Path(s.tempDir).Join("in", "x.txt")
+	s.Require().NoError(src.WriteText(_testContent))
+
+	dest, err := src.RelocateWithSuffix("out", ".json")
+	s.Require().NoError(err)
+	s.Equal(Path(s.tempDir).Join("out", "x.json").String(), dest.String())
+	s.Equal(_testContent, dest.MustReadText())
+	s.NoFileExists(src.String())
+
+	_, err = src.RelocateWithSuffix("out", ".json")
+	s.ErrorIs(err, os.ErrNotExist)
+
+	_, err = Path(s.tempDir).Join("out").RelocateWithSuffix("other", "txt")
+	s.ErrorIs(err, ErrIsDirectory)
+}
+
+func (s *PathSuite) TestSwapWith() {
+	active := Path(s.createTempFile("active.yaml", "blue"))
+	standby := Path(s.createTempFile("standby.yaml", "green"))
+
+	s.Require().NoError(active.SwapWith(standby))
+	s.Equal("green", active.MustReadText())
+	s.Equal("blue", standby.MustReadText())
+
+	s.ErrorIs(active.SwapWith(Path(s.tempDir).Join("missing.yaml")), os.ErrNotExist)
+	s.Equal("green", active.MustReadText())
+
+	memFs := afero.NewMemMapFs()
+	memActive := Path("/mem/active.yaml").WithFs(memFs)
+	memStandby := Path("/mem/standby.yaml").WithFs(memFs)
+	s.Require().NoError(memActive.WriteText("blue"))
+	s.Require().NoError(memStandby.WriteText("green"))
+
+	s.Require().NoError(memActive.SwapWith(memStandby), "falls back to renames on other file systems")
+	s.Equal("green", memActive.MustReadText())
+	s.Equal("blue", memStandby.MustReadText())
+
+	entries, err := afero.ReadDir(memFs, "/mem")
+	s.Require().NoError(err)
+	s.Len(entries, 2, "no temporary file should remain")
+}
+
+func (s *PathSuite) TestCopyInto() {
+	src := Path(s.createTempFile("report.txt", _testContent))
+	s.Require().NoError(os.Chmod(src.String(), 0o600))
+
+	copied, err := src.CopyInto(filepath.Join(s.tempDir, "backup", "2024"))
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(s.tempDir, "backup", "2024", "report.txt"), copied.String())
+	s.Equal(_testContent, copied.MustReadText())
+	s.FileExists(src.String())
+
+	info, err := os.Stat(copied.String())
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	_, err = Path(s.tempDir).Join("missing.txt").CopyInto(s.tempDir)
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *PathSuite) TestMoveInto() {
+	src := Path(s.createTempFile("moved.txt", _testContent))
+
+	moved, err := src.MoveInto(filepath.Join(s.tempDir, "archive"))
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(s.tempDir, "archive", "moved.txt"), moved.String())
+	s.Equal(_testContent, moved.MustReadText())
+	s.NoFileExists(src.String())
+}
+
+func (s *PathSuite) TestCopyWithMeta() {
+	src := Path(s.createTempFile("dump.sql", _testContent))
+	s.Require().NoError(os.Chmod(src.String(), 0o600))
+
+	atime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	mtime := time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC)
+	s.Require().NoError(os.Chtimes(src.String(), atime, mtime))
+
+	dest := filepath.Join(s.tempDir, "dump-copy.sql")
+	s.Require().NoError(src.CopyWithMeta(dest, true))
+
+	info, err := os.Stat(dest)
+	s.Require().NoError(err)
+	s.True(mtime.Equal(info.ModTime()), "got mtime %v", info.ModTime())
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+	s.Equal(_testContent, Path(dest).MustReadText())
+
+	if got, _, _, ok := fileMeta(info); ok {
+		s.True(atime.Equal(got), "got atime %v", got)
+	}
+
+	memFs := afero.NewMemMapFs()
+	memSrc := Path("/src.txt").WithFs(memFs)
+	s.Require().NoError(memSrc.WriteText("x"))
+	s.Require().NoError(memFs.Chtimes("/src.txt", atime, mtime))
+	s.Require().NoError(memSrc.CopyWithMeta("/dst.txt"))
+
+	memInfo, err := memFs.Stat("/dst.txt")
+	s.Require().NoError(err)
+	s.True(mtime.Equal(memInfo.ModTime()))
+
+	s.ErrorIs(memSrc.CopyWithMeta("/owned.txt", true), ErrNotSupported)
+	s.ErrorIs(Path(s.tempDir).Join("missing").CopyWithMeta(dest), os.ErrNotExist)
+}
+
+func (s *PathSuite) TestCopyThrottled() {
+	content := strings.Repeat("x", 2048)
+	src := Path(s.createTempFile("throttled.bin", content))
+	s.Require().NoError(os.Chmod(src.String(), 0o600))
+
+	dest := filepath.Join(s.tempDir, "throttled_copy.bin")
+
+	start := time.Now()
+	written, err := src.CopyThrottled(dest, 4096)
+	s.Require().NoError(err)
+	s.GreaterOrEqual(time.Since(start), 500*time.Millisecond, "2048 bytes at 4096 B/s take at least 0.5s")
+	s.Equal(int64(len(content)), written)
+	s.Equal(content, Path(dest).MustReadText())
+
+	info, err := os.Stat(dest)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	start = time.Now()
+	written, err = src.CopyThrottled(dest, 0)
+	s.Require().NoError(err)
+	s.Equal(int64(len(content)), written)
+	s.Less(time.Since(start), 500*time.Millisecond)
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package pathlib
+
+import (
+	"strings"
+)
+
+// isHidden reports whether the file at absPath with base name name is hidden:
+// on Unix-like systems, whether the name starts with a dot.
+func isHidden(_, name string) bool {
+	return strings.HasPrefix(name, ".")
+}
@@ -0,0 +1,27 @@
+package pathlib
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// isHidden reports whether the file at absPath with base name name is hidden:
+// on Windows, whether it has the hidden attribute, or a dot-prefixed name as created by Unix tools.
+func isHidden(absPath, name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	pathPtr, err := windows.UTF16PtrFromString(absPath)
+	if err != nil {
+		return false
+	}
+
+	attrs, err := windows.GetFileAttributes(pathPtr)
+	if err != nil {
+		return false
+	}
+
+	return attrs&windows.FILE_ATTRIBUTE_HIDDEN != 0
+}
@@ -0,0 +1,213 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEnvTarget = errors.New("target must be a non-nil pointer to a struct")
+
+// GetJSONWithEnv loads the JSON file into v like GetJSON, then overrides fields of v from
+// environment variables, following the twelve-factor config pattern.
+//
+// The variable for a field is PREFIX_NAME, where NAME is the `env` struct tag if set, otherwise
+// the `json` tag name or the field name, upper-cased. Nested structs (and pointers to structs)
+// are handled recursively with underscore-joined names, e.g. APP_DB_HOST for the Host field of
+// the DB field with prefix "APP". A nil pointer to a struct is only allocated when a variable
+// below its name is set, e.g. APP_CACHE_PORT for the Cache field. A field tagged `env:"-"` is
+// never overridden. With an empty prefix, the names are used without prefix.
+//
+// Supported field types are strings, booleans, integers, unsigned integers, floats and
+// time.Duration (parsed with time.ParseDuration). Variables that are not set leave the
+// file value untouched; a variable set to the empty string overrides a string field with "".
+//
+// Parameters:
+//   - v: A non-nil pointer to a struct.
+//   - prefix: The environment variable prefix, e.g. "APP".
+//
+// Returns:
+//   - error: An error wrapping ErrInvalidEnvTarget if v is not a pointer to a struct, an error if
+//     the file cannot be read or unmarshaled, or an error naming the variable if its value cannot
+//     be parsed or the field type is not supported.
+//
+// Example usage:
+//
+//	type Config struct {
+//	    Port int `json:"port"`
+//	    DB   struct {
+//	        Host string `json:"host"`
+//	    } `json:"db"`
+//	}
+//
+//	// APP_PORT=9090 APP_DB_HOST=db.internal
+//	var config Config
+//	err := Path("/etc/app/config.json").GetJSONWithEnv(&config, "APP")
+func (p *FsPath) GetJSONWithEnv(v interface{}, prefix string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: got %T", ErrInvalidEnvTarget, v)
+	}
+
+	if err := p.GetJSON(v); err != nil {
+		return err
+	}
+
+	return applyEnv(rv.Elem(), strings.ToUpper(prefix))
+}
+
+// applyEnv overrides the exported fields of the struct value rv from environment variables named
+// after prefix and the field names.
+func applyEnv(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := envFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		value := rv.Field(i)
+
+		if err := applyEnvValue(value, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyEnvValue sets value from the environment variable name, or recurses if value is a struct.
+func applyEnvValue(value reflect.Value, name string) error {
+	if value.Kind() == reflect.Pointer && value.Type().Elem().Kind() == reflect.Struct {
+		if value.IsNil() {
+			// only allocate nested structs that actually get overridden; this also stops
+			// self-referential types like a linked list node from recursing forever
+			if !envHasPrefix(name + "_") {
+				return nil
+			}
+
+			nested := reflect.New(value.Type().Elem())
+			if err := applyEnv(nested.Elem(), name); err != nil {
+				return err
+			}
+
+			if !nested.Elem().IsZero() {
+				value.Set(nested)
+			}
+
+			return nil
+		}
+
+		return applyEnv(value.Elem(), name)
+	}
+
+	if value.Kind() == reflect.Struct && value.Type() != reflect.TypeOf(time.Time{}) {
+		return applyEnv(value, name)
+	}
+
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	if err := setFromString(value, raw); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// envHasPrefix reports whether any environment variable name starts with prefix.
+func envHasPrefix(prefix string) bool {
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// envFieldName returns the upper-cased variable name for field, or "" if it is excluded.
+func envFieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("env"); ok {
+		if tag == "-" {
+			return ""
+		}
+
+		return strings.ToUpper(tag)
+	}
+
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return strings.ToUpper(name)
+		}
+	}
+
+	return strings.ToUpper(field.Name)
+}
+
+// setFromString parses raw according to the kind of value and assigns it.
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package pathlib
+
+import (
+	"time"
+)
+
+type envDBConfig struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type envConfig struct {
+	Name    string        `json:"name"`
+	Port    int           `json:"port"`
+	Debug   bool          `json:"debug"`
+	Timeout time.Duration `json:"timeout"`
+	Token   string        `json:"token" env:"API_TOKEN"`
+	Secret  string        `json:"secret" env:"-"`
+	DB      envDBConfig   `json:"db"`
+	Cache   *envDBConfig  `json:"cache"`
+}
+
+func (s *PathSuite) TestGetJSONWithEnv() {
+	file := Path(s.createTempFile("config.json",
+		`{"name": "app", "port": 8080, "secret": "file", "db": {"host": "localhost", "port": 5432}}`))
+
+	s.T().Setenv("APP_PORT", "9090")
+	s.T().Setenv("APP_DEBUG", "true")
+	s.T().Setenv("APP_TIMEOUT", "1m30s")
+	s.T().Setenv("APP_API_TOKEN", "t0ken")
+	s.T().Setenv("APP_SECRET", "env")
+	s.T().Setenv("APP_DB_HOST", "db.internal")
+	s.T().Setenv("APP_CACHE_PORT", "6379")
+
+	var config envConfig
+	s.Require().NoError(file.GetJSONWithEnv(&config, "app"))
+
+	s.Equal("app", config.Name, "unset variables keep the file value")
+	s.Equal(9090, config.Port)
+	s.True(config.Debug)
+	s.Equal(90*time.Second, config.Timeout)
+	s.Equal("t0ken", config.Token)
+	s.Equal("file", config.Secret)
+	s.Equal(envDBConfig{Host: "db.internal", Port: 5432}, config.DB)
+	s.Require().NotNil(config.Cache)
+	s.Equal(6379, config.Cache.Port)
+
+	s.T().Setenv("APP_PORT", "not-a-number")
+	err := file.GetJSONWithEnv(&config, "APP")
+	s.ErrorContains(err, "APP_PORT")
+
+	s.ErrorIs(file.GetJSONWithEnv(config, "APP"), ErrInvalidEnvTarget)
+}
+
+type envNode struct {
+	Name string   `json:"name"`
+	Next *envNode `json:"next"`
+}
+
+func (s *PathSuite) TestGetJSONWithEnvSelfReferential() {
+	file := Path(s.createTempFile("list.json", `{"name": "head"}`))
+
+	s.T().Setenv("LIST_NEXT_NEXT_NAME", "third")
+
+	var head envNode
+	s.Require().NoError(file.GetJSONWithEnv(&head, "LIST"))
+
+	s.Equal("head", head.Name)
+	s.Require().NotNil(head.Next)
+	s.Require().NotNil(head.Next.Next)
+	s.Equal("third", head.Next.Next.Name)
+	s.Nil(head.Next.Next.Next, "no variable below LIST_NEXT_NEXT_NEXT, so nothing is allocated")
+}
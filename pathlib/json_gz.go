@@ -0,0 +1,87 @@
+package pathlib
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GetJSONGz reads a gzip-compressed JSON file and unmarshals its content into v.
+//
+// The file is decompressed and decoded as a stream, so the uncompressed JSON is never held in
+// memory as a whole.
+//
+// Parameters:
+//   - v: A pointer to the variable where the unmarshaled data should be stored.
+//
+// Returns:
+//   - error: An error if the file cannot be opened, is not valid gzip, or doesn't contain valid JSON.
+//
+// Example usage:
+//
+//	var state State
+//	if err := Path("state.json.gz").GetJSONGz(&state); err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) GetJSONGz(v interface{}) error {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	audit(AuditRead, p.absPath)
+
+	gzr, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gzr.Close()
+
+	if err := json.NewDecoder(gzr).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// SetJSONGz marshals v as JSON and atomically replaces the file with it, gzip-compressed,
+// creating the parent directory if needed.
+//
+// v is encoded straight into the gzip stream, which is written to a temporary file in the same
+// directory and renamed over the destination, so the uncompressed JSON is never buffered as a
+// whole and a failed encode never leaves a truncated file behind. The mode of an existing file
+// is preserved. The result can be read back with GetJSONGz, or with any gzip-aware JSON reader.
+//
+// Parameters:
+//   - v: The value to marshal.
+//
+// Returns:
+//   - error: An error if the parent directory or the temporary file cannot be created, v cannot
+//     be marshaled, or the data cannot be written. In that case the existing file is left
+//     untouched.
+//
+// Example usage:
+//
+//	err := Path("state.json.gz").SetJSONGz(state)
+func (p *FsPath) SetJSONGz(v interface{}) error {
+	pr, pw := io.Pipe()
+	// unblocks the encoder if writeAtomicFrom returns before reading everything
+	defer pr.Close()
+
+	go func() {
+		gzw := gzip.NewWriter(pw)
+
+		if err := json.NewEncoder(gzw).Encode(v); err != nil {
+			pw.CloseWithError(fmt.Errorf("failed to encode JSON: %w", err))
+			return
+		}
+
+		pw.CloseWithError(gzw.Close())
+	}()
+
+	_, err := p.writeAtomicFrom(pr)
+
+	return err
+}
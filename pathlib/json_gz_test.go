@@ -0,0 +1,48 @@
+package pathlib
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+)
+
+func (s *PathSuite) TestSetJSONGzGetJSONGz() {
+	type state struct {
+		Name  string         `json:"name"`
+		Count int            `json:"count"`
+		Tags  []string       `json:"tags"`
+		Meta  map[string]int `json:"meta"`
+	}
+
+	want := state{Name: "crawler", Count: 42, Tags: []string{"a", "b"}, Meta: map[string]int{"pages": 7}}
+
+	file := Path(filepath.Join(s.tempDir, "nested", "state.json.gz"))
+	s.Require().NoError(file.SetJSONGz(want))
+
+	// the file is real gzip
+	raw, err := os.Open(file.String())
+	s.Require().NoError(err)
+	defer raw.Close()
+
+	_, err = gzip.NewReader(raw)
+	s.NoError(err)
+
+	var got state
+	s.Require().NoError(file.GetJSONGz(&got))
+	s.Equal(want, got)
+
+	plain := Path(s.createTempFile("plain.json", `{"name": "x"}`))
+	s.Error(plain.GetJSONGz(&got))
+
+	s.Error(Path(filepath.Join(s.tempDir, "missing.json.gz")).GetJSONGz(&got))
+	s.Error(file.SetJSONGz(make(chan int)))
+
+	// a failed encode leaves the previous content in place, and no temporary file behind
+	got = state{}
+	s.Require().NoError(file.GetJSONGz(&got))
+	s.Equal(want, got)
+
+	entries, err := os.ReadDir(file.Parent().String())
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
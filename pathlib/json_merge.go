@@ -0,0 +1,192 @@
+package pathlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNotJSONObject = errors.New("JSON value is not an object")
+
+// MergeJSON applies an RFC 7386 JSON merge patch to the JSON object stored in the file
+// and writes the result back atomically.
+//
+// Patch keys override existing ones, nested objects are merged recursively, arrays and
+// other values replace the existing value, and a null value removes the key.
+// Key order is preserved: existing keys keep their position and new keys are appended.
+//
+// Parameters:
+//   - patch: The merge patch, which must be a JSON object.
+//
+// Returns:
+//   - error: An error wrapping ErrNotJSONObject if the file or the patch is not a JSON object,
+//     or any error raised while reading, parsing or writing the file. On error the file is
+//     left untouched.
+//
+// Example usage:
+//
+//	// config.json: {"server": {"host": "localhost", "port": 80}, "debug": true}
+//	err := Path("config.json").MergeJSON([]byte(`{"server": {"port": 8080}, "debug": null}`))
+//	// config.json: {"server": {"host": "localhost", "port": 8080}}
+func (p *FsPath) MergeJSON(patch []byte) error {
+	patchObject, err := parseJSONObject(patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	data, err := p.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	target, err := parseJSONObject(data)
+	if err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+	}
+
+	target.merge(patchObject)
+
+	merged, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return p.writeFileAtomic(merged)
+}
+
+// jsonObject is a JSON object that remembers the order of its keys.
+// Values are either *jsonObject or json.RawMessage.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+var jsonNull = []byte("null")
+
+// parseJSONObject parses data, which must hold a single JSON object.
+func parseJSONObject(data []byte) (*jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, ErrNotJSONObject
+	}
+
+	object := &jsonObject{values: make(map[string]interface{})}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := token.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		var value interface{} = raw
+
+		if bytes.HasPrefix(raw, []byte("{")) {
+			if value, err = parseJSONObject(raw); err != nil {
+				return nil, err
+			}
+		}
+
+		object.set(key, value)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unexpected data after the top-level object", ErrNotJSONObject)
+	}
+
+	return object, nil
+}
+
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+func (o *jsonObject) remove(key string) {
+	if _, exists := o.values[key]; !exists {
+		return
+	}
+
+	delete(o.values, key)
+
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// merge applies patch to o following RFC 7386.
+func (o *jsonObject) merge(patch *jsonObject) {
+	for _, key := range patch.keys {
+		switch value := patch.values[key].(type) {
+		case *jsonObject:
+			target, ok := o.values[key].(*jsonObject)
+			if !ok {
+				target = &jsonObject{values: make(map[string]interface{})}
+			}
+
+			target.merge(value)
+			o.set(key, target)
+		case json.RawMessage:
+			if bytes.Equal(value, jsonNull) {
+				o.remove(key)
+			} else {
+				o.set(key, value)
+			}
+		}
+	}
+}
+
+// MarshalJSON writes the object with its keys in order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,50 @@
+package pathlib
+
+func (s *PathSuite) TestMergeJSON() {
+	file := Path(s.createTempFile("config.json", `{
+  "name": "app",
+  "server": {"host": "localhost", "port": 80, "tls": {"enabled": false, "cert": "a.pem"}},
+  "tags": ["a", "b"],
+  "debug": true
+}`))
+
+	err := file.MergeJSON([]byte(`{
+  "server": {"port": 8080, "tls": {"enabled": true}, "timeout": "5s"},
+  "tags": ["c"],
+  "debug": null,
+  "version": 2
+}`))
+	s.Require().NoError(err)
+
+	s.Equal(`{
+  "name": "app",
+  "server": {
+    "host": "localhost",
+    "port": 8080,
+    "tls": {
+      "enabled": true,
+      "cert": "a.pem"
+    },
+    "timeout": "5s"
+  },
+  "tags": [
+    "c"
+  ],
+  "version": 2
+}`, file.MustReadText())
+
+	s.Require().ErrorIs(file.MergeJSON([]byte(`["not", "an", "object"]`)), ErrNotJSONObject)
+
+	array := Path(s.createTempFile("array.json", `[1, 2]`))
+	s.Require().ErrorIs(array.MergeJSON([]byte(`{"a": 1}`)), ErrNotJSONObject)
+	s.Equal(`[1, 2]`, array.MustReadText())
+
+	// trailing data after the object is rejected, whether it is a valid token or not
+	before := file.MustReadText()
+	s.Require().ErrorIs(file.MergeJSON([]byte(`{"a": 1} x`)), ErrNotJSONObject)
+	s.Require().ErrorIs(file.MergeJSON([]byte(`{"a": 1} {"b": 2}`)), ErrNotJSONObject)
+
+	garbage := Path(s.createTempFile("garbage.json", `{"a": 1} x`))
+	s.Require().ErrorIs(garbage.MergeJSON([]byte(`{"b": 2}`)), ErrNotJSONObject)
+	s.Equal(before, file.MustReadText())
+}
@@ -0,0 +1,275 @@
+package pathlib
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+var ErrNotJSONArray = errors.New("JSON value is not an array")
+
+// JSONArrayLength returns the number of elements of the top-level JSON array stored in the file,
+// e.g. to size a progress bar before processing a large export.
+//
+// The file is streamed with a json.Decoder and each element is skipped token by token, so memory
+// use stays constant regardless of the size of the array and of its elements.
+//
+// Returns:
+//   - int: The number of elements in the array.
+//   - error: An error wrapping ErrNotJSONArray if the top-level value is not an array, or an error
+//     if the file cannot be read or contains invalid JSON.
+//
+// Example usage:
+//
+//	total, err := Path("/data/export.json").JSONArrayLength()
+//	if err != nil {
+//		// handle error
+//	}
+//	bar := progressbar.New(total)
+func (p *FsPath) JSONArrayLength() (int, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("%w: %s", ErrNotJSONArray, p.absPath)
+	}
+
+	count := 0
+
+	for dec.More() {
+		if err := skipJSONValue(dec); err != nil {
+			return 0, fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+		}
+
+		count++
+	}
+
+	// consume the closing bracket, so a truncated array is reported as an error
+	if _, err := dec.Token(); err != nil {
+		return 0, fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+	}
+
+	return count, nil
+}
+
+// EachJSONObjectField streams the top-level JSON object stored in the file and calls fn with
+// each key and its raw value, in file order, e.g. to process a large map of id to record
+// without loading the whole object.
+//
+// Only one value is held in memory at a time; decode it with json.Unmarshal as needed.
+// The walk stops at the first error returned by fn, which is returned as is.
+//
+// Parameters:
+//   - fn: The function called for each field, with the key and the undecoded value.
+//
+// Returns:
+//   - error: An error wrapping ErrNotJSONObject if the top-level value is not an object, an error
+//     if the file cannot be read or contains invalid JSON, or the error returned by fn.
+//
+// Example usage:
+//
+//	err := Path("/data/users.json").EachJSONObjectField(func(id string, value json.RawMessage) error {
+//		var user User
+//		if err := json.Unmarshal(value, &user); err != nil {
+//			return err
+//		}
+//		return index(id, user)
+//	})
+func (p *FsPath) EachJSONObjectField(fn func(key string, value json.RawMessage) error) error {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("%w: %s", ErrNotJSONObject, p.absPath)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+		}
+
+		// inside an object the decoder only yields string keys here
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+		}
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	// consume the closing brace, so a truncated object is reported as an error
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", p.absPath, err)
+	}
+
+	return nil
+}
+
+// skipJSONValue reads the next value from dec token by token, without keeping it in memory.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '[', '{':
+				depth++
+			default:
+				depth--
+			}
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// JSONArrayWriter writes a JSON array to a file one element at a time, so arrays of any size
+// can be produced without holding all elements in memory. Writes are buffered; the array is
+// only complete once Close has been called.
+//
+// A JSONArrayWriter is safe for concurrent use.
+type JSONArrayWriter struct {
+	mu sync.Mutex
+
+	path   *FsPath
+	file   afero.File
+	buf    *bufio.Writer
+	count  int
+	closed bool
+}
+
+// JSONArrayWriter creates or truncates the file, creating its parent directory if needed,
+// and returns a JSONArrayWriter that writes a JSON array to it.
+//
+// Example usage:
+//
+//	w, err := Path("/data/export.json").JSONArrayWriter()
+//	if err != nil {
+//		// handle error
+//	}
+//	for rows.Next() {
+//		if err := w.Append(scan(rows)); err != nil {
+//			// handle error
+//		}
+//	}
+//	if err := w.Close(); err != nil {
+//		// handle error
+//	}
+func (p *FsPath) JSONArrayWriter() (*JSONArrayWriter, error) {
+	if err := p.MkParentDir(); err != nil {
+		return nil, err
+	}
+
+	file, err := p.fs.Create(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bufio.NewWriter(file)
+	if err := buf.WriteByte('['); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	audit(AuditWrite, p.absPath)
+
+	return &JSONArrayWriter{path: p, file: file, buf: buf}, nil
+}
+
+// Append marshals v and writes it as the next element of the array.
+// It returns ErrWriterClosed after Close, and leaves the array unchanged if v cannot be marshaled.
+func (w *JSONArrayWriter) Append(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	if w.count > 0 {
+		if err := w.buf.WriteByte(','); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.buf.Write(data); err != nil {
+		return err
+	}
+
+	w.count++
+
+	return nil
+}
+
+// Count returns the number of elements appended so far.
+func (w *JSONArrayWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.count
+}
+
+// Close writes the closing bracket, flushes buffered elements and closes the file.
+// Calling Close more than once is a no-op.
+func (w *JSONArrayWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	err := w.buf.WriteByte(']')
+	if err == nil {
+		err = w.buf.Flush()
+	}
+
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,108 @@
+package pathlib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+func (s *PathSuite) TestJSONArrayLength() {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"objects", `[{"id": 1, "tags": ["a", "b"]}, {"id": 2, "nested": {"x": [1, {"y": 2}]}}, {}]`, 3},
+		{"scalars", `[1, "two", true, null, 5.5]`, 5},
+		{"nested arrays", `[[1, 2], [], [[3]]]`, 3},
+		{"empty", `[]`, 0},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			count, err := Path(s.createTempFile(tt.name+".json", tt.content)).JSONArrayLength()
+			s.Require().NoError(err)
+			s.Equal(tt.want, count)
+		})
+	}
+
+	_, err := Path(s.createTempFile("object.json", `{"a": [1, 2]}`)).JSONArrayLength()
+	s.ErrorIs(err, ErrNotJSONArray)
+
+	_, err = Path(s.createTempFile("scalar.json", `42`)).JSONArrayLength()
+	s.ErrorIs(err, ErrNotJSONArray)
+
+	_, err = Path(s.createTempFile("truncated.json", `[1, 2, {"a": `)).JSONArrayLength()
+	s.Error(err)
+}
+
+func (s *PathSuite) TestEachJSONObjectField() {
+	file := Path(s.createTempFile("users.json",
+		`{"u1": {"name": "ann", "tags": ["a"]}, "u2": 42, "u3": [1, {"x": null}], "u4": "text"}`))
+
+	var (
+		keys   []string
+		values []string
+	)
+
+	s.Require().NoError(file.EachJSONObjectField(func(key string, value json.RawMessage) error {
+		keys = append(keys, key)
+		values = append(values, string(value))
+
+		return nil
+	}))
+	s.Equal([]string{"u1", "u2", "u3", "u4"}, keys)
+	s.Equal([]string{`{"name": "ann", "tags": ["a"]}`, `42`, `[1, {"x": null}]`, `"text"`}, values)
+
+	errEnough := errors.New("enough")
+	visited := 0
+
+	err := file.EachJSONObjectField(func(string, json.RawMessage) error {
+		visited++
+		if visited == 2 {
+			return errEnough
+		}
+
+		return nil
+	})
+	s.ErrorIs(err, errEnough)
+	s.Equal(2, visited)
+
+	noop := func(string, json.RawMessage) error { return nil }
+
+	s.NoError(Path(s.createTempFile("empty.json", `{}`)).EachJSONObjectField(noop))
+	s.ErrorIs(Path(s.createTempFile("array.json", `[1, 2]`)).EachJSONObjectField(noop), ErrNotJSONObject)
+	s.Error(Path(s.createTempFile("truncated.json", `{"a": 1, "b": `)).EachJSONObjectField(noop))
+}
+
+func (s *PathSuite) TestJSONArrayWriter() {
+	type record struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	file := Path(s.tempDir).Join("export", "records.json")
+
+	w, err := file.JSONArrayWriter()
+	s.Require().NoError(err)
+
+	for i := 1; i <= 3; i++ {
+		s.Require().NoError(w.Append(record{ID: i, Name: fmt.Sprintf("r%d", i)}))
+	}
+
+	s.Error(w.Append(func() {}), "unmarshalable values are rejected")
+	s.Equal(3, w.Count())
+	s.Require().NoError(w.Close())
+	s.NoError(w.Close())
+	s.ErrorIs(w.Append(record{}), ErrWriterClosed)
+
+	var got []record
+	s.Require().NoError(file.GetJSON(&got))
+	s.Equal([]record{{1, "r1"}, {2, "r2"}, {3, "r3"}}, got)
+
+	empty := Path(s.tempDir).Join("empty.json")
+	w, err = empty.JSONArrayWriter()
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	s.Equal("[]", empty.MustReadText())
+}
@@ -0,0 +1,73 @@
+package pathlib
+
+import (
+	"sort"
+	"strings"
+)
+
+// CreateLayout creates a tree of files and directories under root from spec, e.g. to scaffold
+// a project or build test fixtures.
+//
+// Each key of spec is a slash-separated path relative to root. A key ending with "/" creates
+// a directory (its value is ignored); any other key creates a file with the value as content,
+// so an empty value creates an empty file. Parent directories are created as needed with
+// DefaultDirMode, and existing files are overwritten.
+//
+// Parameters:
+//   - root: The directory to create the layout in. It is created if it doesn't exist.
+//   - spec: The entries to create, mapping relative paths to file contents.
+//
+// Returns:
+//   - error: An error wrapping ErrIllegalFilePath if a key is absolute or escapes root,
+//     or an error if an entry cannot be created. Entries are created in sorted key order,
+//     and creation stops at the first error; invalid keys are reported before anything is created.
+//
+// Example:
+//
+//	err := CreateLayout("/tmp/project", map[string]string{
+//	    "README.md":         "# Project\n",
+//	    "cmd/app/main.go":   "package main\n",
+//	    "internal/":         "",
+//	    "testdata/empty.txt": "",
+//	})
+func CreateLayout(root string, spec map[string]string) error {
+	rootPath := Path(root)
+
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	entries := make([]*FsPath, len(keys))
+
+	for i, key := range keys {
+		entry, err := rootPath.SafeJoin(key)
+		if err != nil {
+			return err
+		}
+
+		entries[i] = entry
+	}
+
+	if err := rootPath.Mkdirs(); err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		if strings.HasSuffix(key, "/") {
+			if err := entries[i].Mkdirs(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := entries[i].WriteText(spec[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package pathlib
+
+import (
+	"path/filepath"
+)
+
+func (s *PathSuite) TestCreateLayout() {
+	root := filepath.Join(s.tempDir, "project")
+
+	err := CreateLayout(root, map[string]string{
+		"README.md":          "# Project\n",
+		"cmd/app/main.go":    "package main\n",
+		"internal/":          "",
+		"testdata/empty.txt": "",
+		"a/b/c/":             "ignored",
+	})
+	s.Require().NoError(err)
+
+	s.Equal("# Project\n", Path(root).Join("README.md").MustReadText())
+	s.Equal("package main\n", Path(root).Join("cmd", "app", "main.go").MustReadText())
+	s.DirExists(filepath.Join(root, "internal"))
+	s.DirExists(filepath.Join(root, "a", "b", "c"))
+	s.FileExists(filepath.Join(root, "testdata", "empty.txt"))
+	s.Empty(Path(root).Join("testdata", "empty.txt").MustReadText())
+
+	err = CreateLayout(filepath.Join(s.tempDir, "unsafe"), map[string]string{
+		"ok.txt":         "ok",
+		"../escape.txt":  "nope",
+		"/etc/passwd.go": "nope",
+	})
+	s.ErrorIs(err, ErrIllegalFilePath)
+	s.NoDirExists(filepath.Join(s.tempDir, "unsafe"))
+	s.NoFileExists(filepath.Join(s.tempDir, "escape.txt"))
+}
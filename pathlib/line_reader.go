@@ -0,0 +1,282 @@
+package pathlib
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidLineRange = errors.New("invalid line range")
+
+// maxLinesPrealloc caps the capacity GetLinesRange preallocates for the requested lines.
+const maxLinesPrealloc = 1024
+
+// errStopLines is returned by an eachLine callback to stop reading without an error.
+var errStopLines = errors.New("stop reading lines")
+
+// eachLine streams the file line by line and calls fn with the 1-based line number and the line.
+//
+// Lines are split and trimmed the same way as GetLines: the trailing "\n" and "\r" are removed,
+// and empty lines at the end of the file are not reported. Returning errStopLines from fn
+// stops reading and eachLine returns nil.
+func (p *FsPath) eachLine(fn func(lineNum int, line string) error) error {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var (
+		reader       = bufio.NewReader(file)
+		lineNum      = 0
+		pendingEmpty = 0 // empty lines held back until a non-empty line follows
+	)
+
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return readErr
+		}
+
+		if line == "" && readErr != nil {
+			return nil
+		}
+
+		line = strings.TrimRight(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			pendingEmpty++
+		} else {
+			for ; pendingEmpty > 0; pendingEmpty-- {
+				lineNum++
+
+				if err := fn(lineNum, ""); err != nil {
+					return ignoreStopLines(err)
+				}
+			}
+
+			lineNum++
+
+			if err := fn(lineNum, line); err != nil {
+				return ignoreStopLines(err)
+			}
+		}
+
+		if readErr != nil {
+			return nil
+		}
+	}
+}
+
+func ignoreStopLines(err error) error {
+	if errors.Is(err, errStopLines) {
+		return nil
+	}
+
+	return err
+}
+
+// GetLinesRange streams the file and returns count lines starting at the 0-based line index start,
+// i.e. the lines [start, start+count) of GetLines, without loading the whole file.
+//
+// Reading stops as soon as the requested lines are collected, which makes it suitable for
+// paging through large files. Lines are split and trimmed like GetLines.
+//
+// Parameters:
+//   - start: The 0-based index of the first line to return.
+//   - count: The maximum number of lines to return.
+//
+// Returns:
+//   - []string: The requested lines; fewer than count if the file ends first, and empty if
+//     start is past the end of the file.
+//   - error: An error wrapping ErrInvalidLineRange if start or count is negative, or an error
+//     if the file cannot be opened or read.
+//
+// Example usage:
+//
+//	const pageSize = 50
+//	lines, err := Path("/var/log/app.log").GetLinesRange(page*pageSize, pageSize)
+func (p *FsPath) GetLinesRange(start, count int) ([]string, error) {
+	if start < 0 || count < 0 {
+		return nil, fmt.Errorf("%w: start=%d, count=%d", ErrInvalidLineRange, start, count)
+	}
+
+	// count comes from the caller and may be huge, so don't trust it for the allocation
+	lines := make([]string, 0, min(count, maxLinesPrealloc))
+	if count == 0 {
+		return lines, nil
+	}
+
+	err := p.eachLine(func(lineNum int, line string) error {
+		if lineNum <= start {
+			return nil
+		}
+
+		lines = append(lines, line)
+		if len(lines) == count {
+			return errStopLines
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// LinesChan streams the file line by line over a channel, for pipeline-style processing.
+//
+// Lines are split and trimmed like GetLines: trailing "\n" and "\r" are removed and
+// empty lines at the end of the file are dropped.
+//
+// Parameters:
+//   - ctx: Cancelling ctx stops the reading goroutine and closes both channels.
+//
+// Returns:
+//   - <-chan string: Receives each line. Closed when the file is exhausted, on error, or on cancellation.
+//   - <-chan error: Receives at most one error (an open/read error, or ctx.Err() on cancellation)
+//     and is then closed. It is buffered, so it never blocks the goroutine.
+//
+// Example usage:
+//
+//	lines, errc := Path("access.log").LinesChan(ctx)
+//	for line := range lines {
+//	    process(line)
+//	}
+//	if err := <-errc; err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) LinesChan(ctx context.Context) (<-chan string, <-chan error) {
+	lines := make(chan string)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(lines)
+
+		err := p.eachLine(func(_ int, line string) error {
+			select {
+			case lines <- line:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errc <- err
+		}
+	}()
+
+	return lines, errc
+}
+
+// GrepOptions holds the options for Grep.
+type GrepOptions struct {
+	// MaxMatches stops the search after this many matches. Zero means no limit.
+	MaxMatches int
+	// InvertMatch selects the lines that do not match the pattern, like grep -v.
+	InvertMatch bool
+	// Before is the number of context lines to include before each match, like grep -B.
+	Before int
+	// After is the number of context lines to include after each match, like grep -A.
+	After int
+}
+
+// GrepMatch represents a single line selected by Grep.
+type GrepMatch struct {
+	// LineNumber is the 1-based line number of the match.
+	LineNumber int
+	// Line is the matching line, without the line ending.
+	Line string
+	// Before holds up to GrepOptions.Before lines preceding the match.
+	Before []string
+	// After holds up to GrepOptions.After lines following the match.
+	After []string
+}
+
+// Grep searches the file line by line for lines matching re, without loading the whole file.
+//
+// Parameters:
+//   - re: The regular expression to match each line against.
+//   - opts: Options controlling the match limit, inversion and context lines.
+//
+// Returns:
+//   - []GrepMatch: The selected lines in file order, with their line numbers and context.
+//   - error: An error if the file cannot be opened or read.
+//
+// Reading stops as soon as MaxMatches is reached and the After context of the last match is complete.
+// Line endings are handled the same way as GetLines.
+//
+// Example usage:
+//
+//	re := regexp.MustCompile(`ERROR|FATAL`)
+//	matches, err := Path("/var/log/app.log").Grep(re, GrepOptions{MaxMatches: 10, After: 2})
+//	if err != nil {
+//		// handle error
+//	}
+//	for _, m := range matches {
+//		fmt.Printf("%d: %s\n", m.LineNumber, m.Line)
+//	}
+//
+// Note: Unlike grep, context lines are not merged between neighbouring matches,
+// so a line may appear in the context of more than one match.
+func (p *FsPath) Grep(re *regexp.Regexp, opts GrepOptions) ([]GrepMatch, error) {
+	var (
+		matches []GrepMatch
+		before  []string
+		pending []int // indices of matches still collecting After lines
+	)
+
+	err := p.eachLine(func(lineNum int, line string) error {
+		remaining := pending[:0]
+
+		for _, idx := range pending {
+			matches[idx].After = append(matches[idx].After, line)
+			if len(matches[idx].After) < opts.After {
+				remaining = append(remaining, idx)
+			}
+		}
+
+		pending = remaining
+
+		limitReached := opts.MaxMatches > 0 && len(matches) >= opts.MaxMatches
+
+		if !limitReached && re.MatchString(line) != opts.InvertMatch {
+			matches = append(matches, GrepMatch{
+				LineNumber: lineNum,
+				Line:       line,
+				Before:     append([]string(nil), before...),
+			})
+
+			if opts.After > 0 {
+				pending = append(pending, len(matches)-1)
+			}
+
+			limitReached = opts.MaxMatches > 0 && len(matches) >= opts.MaxMatches
+		}
+
+		if opts.Before > 0 {
+			before = append(before, line)
+			if len(before) > opts.Before {
+				before = before[1:]
+			}
+		}
+
+		if limitReached && len(pending) == 0 {
+			return errStopLines
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,106 @@
+package pathlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const _testLog = "INFO start\nERROR first\nINFO running\r\nERROR second\nINFO done\n\n"
+
+func (s *PathSuite) TestGrep() {
+	file := Path(s.createTempFile("app.log", _testLog))
+	re := regexp.MustCompile(`^ERROR`)
+
+	matches, err := file.Grep(re, GrepOptions{})
+	s.Require().NoError(err)
+	s.Require().Len(matches, 2)
+	s.Equal(GrepMatch{LineNumber: 2, Line: "ERROR first"}, matches[0])
+	s.Equal(GrepMatch{LineNumber: 4, Line: "ERROR second"}, matches[1])
+
+	// inverted matching strips "\r" like GetLines and ignores trailing empty lines
+	matches, err = file.Grep(re, GrepOptions{InvertMatch: true})
+	s.Require().NoError(err)
+	s.Require().Len(matches, 3)
+	s.Equal("INFO running", matches[1].Line)
+	s.Equal(5, matches[2].LineNumber)
+
+	// max matches cap
+	matches, err = file.Grep(re, GrepOptions{MaxMatches: 1})
+	s.Require().NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal(2, matches[0].LineNumber)
+
+	// context lines
+	matches, err = file.Grep(re, GrepOptions{MaxMatches: 1, Before: 1, After: 2})
+	s.Require().NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal([]string{"INFO start"}, matches[0].Before)
+	s.Equal([]string{"INFO running", "ERROR second"}, matches[0].After)
+
+	_, err = Path(s.tempDir).Join("missing.log").Grep(re, GrepOptions{})
+	s.Error(err)
+}
+
+func (s *PathSuite) TestLinesChan() {
+	file := Path(s.createTempFile("app.log", _testLog))
+
+	var got []string
+
+	lines, errc := file.LinesChan(context.Background())
+	for line := range lines {
+		got = append(got, line)
+	}
+
+	s.Require().NoError(<-errc)
+	s.Equal(file.MustGetLines(), got)
+	s.Equal("INFO running", got[2])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines, errc = file.LinesChan(ctx)
+
+	s.Equal("INFO start", <-lines)
+	cancel()
+
+	// nobody receives anymore, so the goroutine can only observe the cancellation
+	s.Require().ErrorIs(<-errc, context.Canceled)
+
+	_, open := <-lines
+	s.False(open)
+
+	_, errc = Path(s.tempDir).Join("missing.log").LinesChan(context.Background())
+	s.Require().Error(<-errc)
+}
+
+func (s *PathSuite) TestGetLinesRange() {
+	var content strings.Builder
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&content, "line %d\n", i)
+	}
+
+	file := Path(s.createTempFile("paged.txt", content.String()))
+
+	lines, err := file.GetLinesRange(5, 5)
+	s.Require().NoError(err)
+	s.Equal([]string{"line 5", "line 6", "line 7", "line 8", "line 9"}, lines)
+
+	lines, err = file.GetLinesRange(18, 5)
+	s.Require().NoError(err)
+	s.Equal([]string{"line 18", "line 19"}, lines)
+
+	lines, err = file.GetLinesRange(30, 5)
+	s.Require().NoError(err)
+	s.Empty(lines)
+
+	// a huge count reads to the end of the file instead of preallocating it
+	lines, err = file.GetLinesRange(17, math.MaxInt)
+	s.Require().NoError(err)
+	s.Equal([]string{"line 17", "line 18", "line 19"}, lines)
+
+	_, err = file.GetLinesRange(-1, 5)
+	s.ErrorIs(err, ErrInvalidLineRange)
+	_, err = file.GetLinesRange(0, -1)
+	s.ErrorIs(err, ErrInvalidLineRange)
+}
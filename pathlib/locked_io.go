@@ -0,0 +1,139 @@
+package pathlib
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// ReadLocked reads the whole file while holding a shared lock on it, so it never observes a
+// write made with WriteLocked half-way through. Several readers may hold the lock at once.
+//
+// The lock is advisory: it only coordinates processes and goroutines that use these helpers
+// (or flock themselves). It is released before ReadLocked returns, and only the OS file
+// system is supported.
+//
+// Returns:
+//   - []byte: The content of the file.
+//   - error: An error wrapping ErrNotSupported if the path is not on the OS file system or
+//     the platform has no file locking, or any error while opening, locking or reading.
+//
+// Example usage:
+//
+//	data, err := Path("/var/run/app/state.json").ReadLocked()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (p *FsPath) ReadLocked() ([]byte, error) {
+	file, err := p.openLocked(os.O_RDONLY, false)
+	if err != nil {
+		return nil, err
+	}
+	defer closeLocked(file)
+
+	audit(AuditRead, p.absPath)
+
+	return io.ReadAll(file)
+}
+
+// WriteLocked replaces the content of the file with data while holding an exclusive lock on
+// it, so cooperating readers using ReadLocked never see a partial write. The file and its
+// parent directory are created if needed.
+//
+// The file is truncated only once the lock is held, and the data is synced to disk before the
+// lock is released. Like ReadLocked, the lock is advisory and only the OS file system is supported.
+//
+// Parameters:
+//   - data: The new content of the file.
+//
+// Returns:
+//   - error: An error wrapping ErrNotSupported if the path is not on the OS file system or
+//     the platform has no file locking, or any error while opening, locking or writing.
+//
+// Example usage:
+//
+//	err := Path("/var/run/app/state.json").WriteLocked(data)
+func (p *FsPath) WriteLocked(data []byte) error {
+	if err := p.MkParentDir(); err != nil {
+		return err
+	}
+
+	file, err := p.openLocked(os.O_WRONLY|os.O_CREATE, true)
+	if err != nil {
+		return err
+	}
+	defer closeLocked(file)
+
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+
+	return auditResult(AuditWrite, p.absPath, file.Sync())
+}
+
+// AppendAtomic appends data to the file while holding an exclusive lock on it, so records
+// appended by several processes or goroutines never interleave, even when they are larger
+// than what a single O_APPEND write guarantees to keep together. The file and its parent
+// directory are created if needed.
+//
+// The lock is advisory, so all writers must append with AppendAtomic (or take the lock
+// themselves). It is released before AppendAtomic returns, whether the write succeeded or not.
+// Only the OS file system is supported.
+//
+// Parameters:
+//   - data: The record to append.
+//
+// Returns:
+//   - error: An error wrapping ErrNotSupported if the path is not on the OS file system or
+//     the platform has no file locking, or any error while opening, locking or writing.
+//
+// Example usage:
+//
+//	err := Path("/var/log/app/audit.log").AppendAtomic(append(record, '\n'))
+func (p *FsPath) AppendAtomic(data []byte) error {
+	if err := p.MkParentDir(); err != nil {
+		return err
+	}
+
+	file, err := p.openLocked(os.O_APPEND|os.O_CREATE|os.O_WRONLY, true)
+	if err != nil {
+		return err
+	}
+	defer closeLocked(file)
+
+	_, err = file.Write(data)
+
+	return auditResult(AuditWrite, p.absPath, err)
+}
+
+// openLocked opens the file on the OS file system with flag and blocks until it holds
+// an exclusive or shared lock on it. The caller releases it with closeLocked.
+func (p *FsPath) openLocked(flag int, exclusive bool) (*os.File, error) {
+	if _, ok := p.fs.(*afero.OsFs); !ok {
+		return nil, fmt.Errorf("%w: file locking requires the OS file system", ErrNotSupported)
+	}
+
+	file, err := os.OpenFile(p.absPath, flag, FileMode644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file, exclusive); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", p.absPath, err)
+	}
+
+	return file, nil
+}
+
+// closeLocked releases the lock taken by openLocked and closes the file.
+func closeLocked(file *os.File) {
+	_ = unlockFile(file)
+	file.Close()
+}
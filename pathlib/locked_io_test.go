@@ -0,0 +1,126 @@
+package pathlib
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func (s *PathSuite) TestReadLockedWriteLocked() {
+	file := Path(filepath.Join(s.tempDir, "state", "data.txt"))
+	s.Require().NoError(file.WriteLocked([]byte("first version, rather long")))
+	s.Require().NoError(file.WriteLocked([]byte("second")))
+
+	data, err := file.ReadLocked()
+	s.Require().NoError(err)
+	s.Equal("second", string(data))
+
+	_, err = Path(filepath.Join(s.tempDir, "missing.txt")).ReadLocked()
+	s.ErrorIs(err, os.ErrNotExist)
+
+	mem := Path("/data.txt").WithFs(afero.NewMemMapFs())
+	s.ErrorIs(mem.WriteLocked([]byte("x")), ErrNotSupported)
+	_, err = mem.ReadLocked()
+	s.ErrorIs(err, ErrNotSupported)
+}
+
+func (s *PathSuite) TestReadLockedWaitsForWriter() {
+	file := Path(s.createTempFile("shared.txt", "old"))
+
+	// hold the writer's lock, like a WriteLocked in progress
+	writer, err := file.openLocked(os.O_WRONLY, true)
+	s.Require().NoError(err)
+
+	read := make(chan string, 1)
+
+	go func() {
+		data, _ := file.ReadLocked()
+		read <- string(data)
+	}()
+
+	select {
+	case <-read:
+		s.FailNow("ReadLocked did not wait for the exclusive lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.Require().NoError(writer.Truncate(0))
+	_, err = writer.WriteString("new")
+	s.Require().NoError(err)
+	closeLocked(writer)
+
+	select {
+	case data := <-read:
+		s.Equal("new", data)
+	case <-time.After(5 * time.Second):
+		s.FailNow("ReadLocked did not acquire the lock after the writer released it")
+	}
+
+	// and a writer waits for the readers
+	reader, err := file.openLocked(os.O_RDONLY, false)
+	s.Require().NoError(err)
+
+	written := make(chan error, 1)
+
+	go func() {
+		written <- file.WriteLocked([]byte("newer"))
+	}()
+
+	select {
+	case <-written:
+		s.FailNow("WriteLocked did not wait for the shared lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	closeLocked(reader)
+	s.Require().NoError(<-written)
+	s.Equal("newer", file.MustGetString())
+}
+
+func (s *PathSuite) TestAppendAtomic() {
+	const (
+		recordSize = 1 << 20
+		perWorker  = 5
+	)
+
+	file := Path(filepath.Join(s.tempDir, "logs", "audit.log"))
+
+	var wg sync.WaitGroup
+
+	for _, c := range []byte{'a', 'b'} {
+		record := append(bytes.Repeat([]byte{c}, recordSize-1), '\n')
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < perWorker; i++ {
+				s.NoError(file.AppendAtomic(record))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	data, err := os.ReadFile(file.String())
+	s.Require().NoError(err)
+	s.Require().Len(data, 2*perWorker*recordSize)
+
+	counts := map[byte]int{}
+
+	for start := 0; start < len(data); start += recordSize {
+		record := data[start : start+recordSize]
+		s.Require().Equal(byte('\n'), record[recordSize-1])
+		s.Require().Equal(recordSize-1, bytes.Count(record, record[:1]), "records must not interleave")
+		counts[record[0]]++
+	}
+
+	s.Equal(map[byte]int{'a': perWorker, 'b': perWorker}, counts)
+
+	s.ErrorIs(Path("/audit.log").WithFs(afero.NewMemMapFs()).AppendAtomic([]byte("x")), ErrNotSupported)
+}
@@ -0,0 +1,174 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+var ErrMergeConflict = errors.New("merge conflict: destination already exists")
+
+// MergeConflictPolicy defines how MergeInto handles an entry that already exists in the destination.
+type MergeConflictPolicy int
+
+const (
+	// MergeConflictError aborts the merge before anything is moved if any entry conflicts. This is the default.
+	MergeConflictError MergeConflictPolicy = iota
+	// MergeConflictSkip keeps the destination entry and leaves the source entry in place.
+	MergeConflictSkip
+	// MergeConflictOverwrite replaces the destination entry with the source entry.
+	MergeConflictOverwrite
+)
+
+// MergeOptions controls MergeInto.
+type MergeOptions struct {
+	// OnConflict is the policy for source entries that already exist in the destination.
+	// Directories existing on both sides are merged recursively and are never a conflict.
+	OnConflict MergeConflictPolicy
+	// RemoveSource removes the source directory once it is empty after the merge.
+	RemoveSource bool
+}
+
+// MergeInto moves the contents of the directory at the current path into destDir, merging
+// subdirectories that exist on both sides recursively, unlike Move which fails or replaces
+// destDir as a whole.
+//
+// Entries are moved with Move, so merging across devices works too. A file that exists in
+// both trees, or an entry that is a file on one side and a directory on the other, is a conflict
+// handled according to opts.OnConflict. Source subdirectories emptied by the merge are removed.
+//
+// Parameters:
+//   - destDir: The destination directory. It is created if it doesn't exist.
+//   - opts: MergeOptions with the conflict policy and whether to remove the source directory.
+//
+// Returns:
+//   - error: An error wrapping ErrNotDirectory if the current path is not a directory, an error
+//     wrapping ErrMergeConflict for the first conflict under MergeConflictError (in which case
+//     nothing is moved), or any error encountered while moving entries.
+//
+// Example:
+//
+//	err := Path("/data/incoming").MergeInto("/data/library", MergeOptions{
+//	    OnConflict:   MergeConflictSkip,
+//	    RemoveSource: true,
+//	})
+//
+// Note: With MergeConflictSkip, skipped entries stay in the source directory, which is then
+// not empty and is kept even if RemoveSource is set.
+func (p *FsPath) MergeInto(destDir string, opts MergeOptions) error {
+	if !p.IsDir() {
+		return fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	dest := p.withSameFs(destDir)
+
+	if opts.OnConflict == MergeConflictError {
+		if err := p.findMergeConflict(dest); err != nil {
+			return err
+		}
+	}
+
+	if err := p.fs.MkdirAll(dest.absPath, DefaultDirMode); err != nil {
+		return err
+	}
+
+	if err := p.mergeChildren(dest, opts.OnConflict); err != nil {
+		return err
+	}
+
+	if opts.RemoveSource {
+		if err := p.Rmdir(); err != nil && !errors.Is(err, ErrDirectoryNotEmpty) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findMergeConflict walks the source tree and returns an ErrMergeConflict error for the first
+// entry that would conflict with dest, or nil if the trees can be merged without conflicts.
+func (p *FsPath) findMergeConflict(dest *FsPath) error {
+	return p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil || relPath == "." {
+			return err
+		}
+
+		target, err := p.fs.Stat(filepath.Join(dest.absPath, relPath))
+		if errors.Is(err, fs.ErrNotExist) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && target.IsDir() {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s", ErrMergeConflict, filepath.Join(dest.absPath, relPath))
+	})
+}
+
+// mergeChildren moves each child of the current directory into dest, recursing into
+// directories that exist on both sides.
+func (p *FsPath) mergeChildren(dest *FsPath, policy MergeConflictPolicy) error {
+	entries, err := afero.ReadDir(p.fs, p.absPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		source := p.withSameFs(filepath.Join(p.absPath, entry.Name()))
+		target := p.withSameFs(filepath.Join(dest.absPath, entry.Name()))
+
+		targetInfo, err := p.fs.Stat(target.absPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			if err := source.Move(target.absPath); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() && targetInfo.IsDir() {
+			if err := source.mergeChildren(target, policy); err != nil {
+				return err
+			}
+
+			if err := source.Rmdir(); err != nil && !errors.Is(err, ErrDirectoryNotEmpty) {
+				return err
+			}
+
+			continue
+		}
+
+		switch policy {
+		case MergeConflictSkip:
+			continue
+		case MergeConflictOverwrite:
+			if err := target.RmTree(); err != nil {
+				return err
+			}
+
+			if err := source.Move(target.absPath); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %s", ErrMergeConflict, target.absPath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package pathlib
+
+func (s *PathSuite) TestMergeInto() {
+	setup := func(name string) (*FsPath, *FsPath) {
+		src := Path(s.tempDir).Join(name, "src")
+		dst := Path(s.tempDir).Join(name, "dst")
+
+		s.Require().NoError(src.Join("new.txt").WriteText("new"))
+		s.Require().NoError(src.Join("shared", "a.txt").WriteText("src a"))
+		s.Require().NoError(src.Join("shared", "b.txt").WriteText("src b"))
+		s.Require().NoError(dst.Join("shared", "a.txt").WriteText("dst a"))
+		s.Require().NoError(dst.Join("keep.txt").WriteText("keep"))
+
+		return src, dst
+	}
+
+	s.Run("error", func() {
+		src, dst := setup("error")
+
+		err := src.MergeInto(dst.String(), MergeOptions{})
+		s.ErrorIs(err, ErrMergeConflict)
+		s.Contains(err.Error(), dst.Join("shared", "a.txt").String())
+
+		// nothing is moved when a conflict is detected
+		s.FileExists(src.Join("new.txt").String())
+		s.NoFileExists(dst.Join("new.txt").String())
+	})
+
+	s.Run("skip", func() {
+		src, dst := setup("skip")
+
+		s.Require().NoError(src.MergeInto(dst.String(), MergeOptions{OnConflict: MergeConflictSkip, RemoveSource: true}))
+		s.Equal("new", dst.Join("new.txt").MustReadText())
+		s.Equal("dst a", dst.Join("shared", "a.txt").MustReadText())
+		s.Equal("src b", dst.Join("shared", "b.txt").MustReadText())
+		s.Equal("keep", dst.Join("keep.txt").MustReadText())
+
+		// the skipped file stays behind, so the source is kept
+		s.Equal("src a", src.Join("shared", "a.txt").MustReadText())
+		s.NoFileExists(src.Join("new.txt").String())
+	})
+
+	s.Run("overwrite", func() {
+		src, dst := setup("overwrite")
+
+		s.Require().NoError(src.MergeInto(dst.String(), MergeOptions{OnConflict: MergeConflictOverwrite, RemoveSource: true}))
+		s.Equal("new", dst.Join("new.txt").MustReadText())
+		s.Equal("src a", dst.Join("shared", "a.txt").MustReadText())
+		s.Equal("src b", dst.Join("shared", "b.txt").MustReadText())
+		s.Equal("keep", dst.Join("keep.txt").MustReadText())
+		s.NoDirExists(src.String())
+	})
+
+	s.ErrorIs(Path(s.createTempFile("file.txt", "")).MergeInto(s.tempDir, MergeOptions{}), ErrNotDirectory)
+}
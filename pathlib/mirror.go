@@ -0,0 +1,184 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// MirrorOptions controls MirrorTo.
+type MirrorOptions struct {
+	// Delete removes files and directories in dest that don't exist in the source.
+	Delete bool
+	// DryRun computes the result without copying or deleting anything.
+	DryRun bool
+}
+
+// MirrorResult holds the counts of a MirrorTo run.
+type MirrorResult struct {
+	// Copied is the number of files that were new or changed and have been copied.
+	Copied int
+	// Deleted is the number of entries removed from dest. A removed directory counts once.
+	Deleted int
+	// Skipped is the number of files that were already up to date.
+	Skipped int
+}
+
+// MirrorTo performs a one-way sync of the directory at the current path into dest,
+// like `rsync -a [--delete]`.
+//
+// A file is copied when it is missing in dest, when the sizes differ, or when the source
+// is newer than the copy (see NewerThan). Copied files get the source modification time,
+// so a second run skips them. Directories are created as needed with their source permissions.
+//
+// Parameters:
+//   - dest: The destination directory. It is created if it doesn't exist.
+//   - opts: MirrorOptions to enable deletion of extraneous entries and dry runs.
+//
+// Returns:
+//   - MirrorResult: The number of copied, deleted and skipped entries. With DryRun,
+//     the counts describe what would have been done.
+//   - error: An error wrapping ErrNotDirectory if the current path is not a directory,
+//     or any error encountered while walking, copying or deleting.
+//
+// Example:
+//
+//	result, err := Path("/data/photos").MirrorTo("/backup/photos", MirrorOptions{Delete: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("copied %d, deleted %d, unchanged %d\n", result.Copied, result.Deleted, result.Skipped)
+func (p *FsPath) MirrorTo(dest string, opts MirrorOptions) (MirrorResult, error) {
+	var result MirrorResult
+
+	if !p.IsDir() {
+		return result, fmt.Errorf("%w: %s", ErrNotDirectory, p.absPath)
+	}
+
+	destRoot := p.withSameFs(dest)
+
+	err := p.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := destRoot.Join(relPath)
+
+		if info.IsDir() {
+			if opts.DryRun {
+				return nil
+			}
+
+			return auditResult(AuditWrite, target.absPath, p.fs.MkdirAll(target.absPath, info.Mode().Perm()))
+		}
+
+		source := p.Join(relPath)
+
+		changed, err := source.changedSince(target, info)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			result.Skipped++
+			return nil
+		}
+
+		result.Copied++
+
+		if opts.DryRun {
+			return nil
+		}
+
+		if err := source.Copy(target.absPath); err != nil {
+			return err
+		}
+
+		return auditResult(AuditWrite, target.absPath, p.fs.Chtimes(target.absPath, info.ModTime(), info.ModTime()))
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if opts.Delete && destRoot.Exists() {
+		deleted, err := p.deleteExtraneous(destRoot, opts.DryRun)
+		result.Deleted = deleted
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// changedSince reports whether the source file (with info) must be copied over target.
+func (p *FsPath) changedSince(target *FsPath, info fs.FileInfo) (bool, error) {
+	targetInfo, err := target.Stat()
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if targetInfo.IsDir() || targetInfo.Size() != info.Size() {
+		return true, nil
+	}
+
+	return p.NewerThan(target)
+}
+
+// deleteExtraneous removes the entries of destRoot that have no counterpart in the source.
+func (p *FsPath) deleteExtraneous(destRoot *FsPath, dryRun bool) (int, error) {
+	var extraneous []string
+
+	err := destRoot.Walk(func(relPath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		sourceInfo, err := p.Join(relPath).Stat()
+		if err == nil && sourceInfo.IsDir() == info.IsDir() {
+			return nil
+		}
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		extraneous = append(extraneous, relPath)
+
+		if info.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Strings(extraneous)
+
+	deleted := 0
+
+	for _, relPath := range extraneous {
+		if !dryRun {
+			if err := destRoot.Join(relPath).RmTree(); err != nil {
+				return deleted, err
+			}
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
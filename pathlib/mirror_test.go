@@ -0,0 +1,54 @@
+package pathlib
+
+import (
+	"os"
+	"time"
+)
+
+func (s *PathSuite) TestMirrorTo() {
+	source := Path(s.tempDir).Join("source")
+	backup := Path(s.tempDir).Join("backup")
+
+	for name, content := range map[string]string{
+		"unchanged.txt":  "same",
+		"changed.txt":    "old",
+		"sub/nested.txt": "nested",
+	} {
+		file := source.Join(name)
+		s.Require().NoError(file.MkParentDir())
+		s.Require().NoError(file.WriteText(content))
+	}
+
+	result, err := source.MirrorTo(backup.String(), MirrorOptions{})
+	s.Require().NoError(err)
+	s.Equal(MirrorResult{Copied: 3}, result)
+
+	// change one file (with a later mtime), add one, and leave an extra file in the backup
+	changed := source.Join("changed.txt")
+	s.Require().NoError(changed.WriteText("new content"))
+	later := time.Now().Add(time.Minute)
+	s.Require().NoError(os.Chtimes(changed.String(), later, later))
+	s.Require().NoError(source.Join("new.txt").WriteText("fresh"))
+	s.Require().NoError(backup.Join("stale", "old.txt").MkParentDir())
+	s.Require().NoError(backup.Join("stale", "old.txt").WriteText("stale"))
+
+	result, err = source.MirrorTo(backup.String(), MirrorOptions{Delete: true, DryRun: true})
+	s.Require().NoError(err)
+	s.Equal(MirrorResult{Copied: 2, Deleted: 1, Skipped: 2}, result)
+	s.Equal("old", backup.Join("changed.txt").MustReadText())
+	s.True(backup.Join("stale").Exists())
+
+	result, err = source.MirrorTo(backup.String(), MirrorOptions{Delete: true})
+	s.Require().NoError(err)
+	s.Equal(MirrorResult{Copied: 2, Deleted: 1, Skipped: 2}, result)
+	s.Equal("new content", backup.Join("changed.txt").MustReadText())
+	s.Equal("fresh", backup.Join("new.txt").MustReadText())
+	s.False(backup.Join("stale").Exists())
+
+	result, err = source.MirrorTo(backup.String(), MirrorOptions{Delete: true})
+	s.Require().NoError(err)
+	s.Equal(MirrorResult{Skipped: 4}, result)
+
+	_, err = changed.MirrorTo(backup.String(), MirrorOptions{})
+	s.Require().ErrorIs(err, ErrNotDirectory)
+}
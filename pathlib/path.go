@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/afero"
 )
@@ -23,6 +24,10 @@ const (
 var (
 	FileMode644 = os.FileMode(_mode644)
 	DirMode755  = os.FileMode(_mode755)
+
+	// DefaultDirMode is the permission used when directories are created implicitly,
+	// e.g. by Mkdirs, MkParentDir, SetBytes or archive extraction. It defaults to 0755.
+	DefaultDirMode = DirMode755
 )
 
 var ErrCannotCreateSiblingDir = errors.New("cannot create sibling directory to parent: current path is at root or one level below")
@@ -81,6 +86,19 @@ type FsPath struct {
 	RawPath string
 
 	fs afero.Fs // The underlying file system
+
+	// pure marks a path created by PurePath: it is cleaned but never resolved against the cwd.
+	pure bool
+
+	// stat caches the Stat result once StatCache has been called.
+	stat *statCache
+}
+
+// statCache holds the cached result of the first Stat call.
+type statCache struct {
+	loaded bool
+	info   fs.FileInfo
+	err    error
 }
 
 // Path creates and returns a new Entity from the given file path
@@ -116,6 +134,57 @@ func PathE(filePath string) (*FsPath, error) {
 	return pth, nil
 }
 
+// PurePath creates a new FsPath from the given file path for pure path manipulation.
+//
+// Unlike Path, the path is only cleaned with filepath.Clean and is never made absolute,
+// so the result does not depend on the current working directory. Path manipulation
+// methods such as Join, Parent, WithName, WithSuffix and Parts keep returning pure paths.
+//
+// Example:
+//
+//	p := PurePath("a/b/../c")
+//	fmt.Println(p.String())           // "a/c"
+//	fmt.Println(p.Join("d").String()) // "a/c/d"
+//
+// Note: A pure path is not meant for filesystem access. Methods that touch the
+// filesystem use the stored path as is, so a relative pure path is resolved against
+// the working directory at call time. Use Path(p.String()) to get an absolute FsPath first.
+func PurePath(filePath string) *FsPath {
+	stem, name, suffix := parseFileName(filepath.Base(filePath))
+
+	return &FsPath{
+		absPath: filepath.Clean(filePath),
+		Stem:    stem,
+		Name:    name,
+		Suffix:  suffix,
+		RawPath: filePath,
+		fs:      afero.NewOsFs(),
+		pure:    true,
+	}
+}
+
+// IsPure reports whether the FsPath was created by PurePath.
+func (p *FsPath) IsPure() bool {
+	return p.pure
+}
+
+// newPath creates a new FsPath for filePath, staying in pure mode if the receiver is a pure path.
+func (p *FsPath) newPath(filePath string) *FsPath {
+	if p.pure {
+		return PurePath(filePath)
+	}
+
+	return Path(filePath)
+}
+
+// withSameFs creates a new FsPath for filePath backed by the receiver's file system.
+func (p *FsPath) withSameFs(filePath string) *FsPath {
+	pth := Path(filePath)
+	pth.fs = p.fs
+
+	return pth
+}
+
 func (p *FsPath) String() string {
 	return p.absPath
 }
@@ -134,31 +203,263 @@ func (p *FsPath) Fs() afero.Fs {
 	return p.fs
 }
 
+// Clone returns an independent copy of the FsPath, sharing the same file system.
+//
+// If StatCache is enabled, the clone gets its own cache holding the current cached result,
+// so Invalidate on one of them does not affect the other.
+func (p *FsPath) Clone() *FsPath {
+	clone := *p
+
+	if p.stat != nil {
+		stat := *p.stat
+		clone.stat = &stat
+	}
+
+	return &clone
+}
+
+// WithFs returns a copy of the FsPath backed by the given file system.
+//
+// Navigation methods such as Join and Parent return paths on the OS file system,
+// so WithFs is the way to move a derived path onto another afero.Fs.
+// Any cached Stat result is dropped, as it belongs to the previous file system.
+//
+// Example:
+//
+//	memFs := afero.NewMemMapFs()
+//	file := Path("/data/config.json").WithFs(memFs)
+//	err := file.WriteText("{}") // written to memFs
+func (p *FsPath) WithFs(fs afero.Fs) *FsPath {
+	clone := p.Clone()
+	clone.fs = fs
+	clone.Invalidate()
+
+	return clone
+}
+
 // Exists check file exists or not.
+//
+// It returns false only if the path genuinely doesn't exist, or if a parent is a file (ENOTDIR).
+// If Stat fails for another reason, e.g. permission denied on a parent directory, the path may
+// exist and Exists returns true; use ExistsErr to tell the two cases apart.
 func (p *FsPath) Exists() bool {
+	exists, err := p.ExistsErr()
+	if err != nil {
+		return !errors.Is(err, syscall.ENOTDIR)
+	}
+
+	return exists
+}
+
+// ExistsErr reports whether the path exists, distinguishing "doesn't exist" from "can't tell".
+//
+// Returns:
+//   - (true, nil) if Stat succeeds.
+//   - (false, nil) if the path doesn't exist (fs.ErrNotExist).
+//   - (false, err) for any other Stat error, e.g. a permission error.
+//
+// Example:
+//
+//	exists, err := Path("/root/secret/config.yaml").ExistsErr()
+//	if err != nil {
+//	    log.Fatalf("cannot check config: %v", err)
+//	}
+func (p *FsPath) ExistsErr() (bool, error) {
 	_, err := p.Stat()
-	return err == nil || os.IsExist(err)
+
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	default:
+		return false, err
+	}
 }
 
+// Stat returns the file info of the path.
+//
+// After StatCache has been called, the first result (including an error) is cached and
+// returned by subsequent calls until Invalidate is called.
 func (p *FsPath) Stat() (fs.FileInfo, error) {
-	return p.fs.Stat(p.absPath)
+	if p.stat == nil {
+		return p.fs.Stat(p.absPath)
+	}
+
+	if !p.stat.loaded {
+		p.stat.info, p.stat.err = p.fs.Stat(p.absPath)
+		p.stat.loaded = true
+	}
+
+	return p.stat.info, p.stat.err
+}
+
+// StatCache enables Stat caching for this FsPath and returns it for chaining.
+//
+// The first Stat is cached and reused by Stat, Exists and IsDir until Invalidate is called,
+// avoiding repeated syscalls when several properties are checked. Changes made to the file
+// afterwards, even through this FsPath, are not seen until Invalidate. Caching is off by default
+// and is not inherited by paths derived with Join, Parent, etc.
+//
+// Example:
+//
+//	p := Path("/data/file.txt").StatCache()
+//	if p.Exists() && !p.IsDir() { // a single Stat syscall
+//	    // ...
+//	}
+func (p *FsPath) StatCache() *FsPath {
+	if p.stat == nil {
+		p.stat = &statCache{}
+	}
+
+	return p
+}
+
+// Invalidate drops the cached Stat result, so the next Stat hits the file system again.
+// It is a no-op when StatCache is not enabled.
+func (p *FsPath) Invalidate() {
+	if p.stat != nil {
+		*p.stat = statCache{}
+	}
+}
+
+// NewerThan reports whether this path was modified after other.
+// It returns an error if either path cannot be stat'ed.
+func (p *FsPath) NewerThan(other *FsPath) (bool, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	otherInfo, err := other.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	return info.ModTime().After(otherInfo.ModTime()), nil
 }
 
 // IsDir checks if the entity is a directory
+//
+// For a pure path, only a trailing slash in the raw path is considered and the filesystem is not checked.
 func (p *FsPath) IsDir() bool {
-	isDir := strings.HasSuffix(p.RawPath, "/")
+	isDir := strings.HasSuffix(p.RawPath, "/") || strings.HasSuffix(p.RawPath, string(filepath.Separator))
 
-	if !isDir {
-		isDir, _ = afero.IsDir(p.fs, p.absPath)
+	if !isDir && !p.pure {
+		info, err := p.Stat()
+		isDir = err == nil && info.IsDir()
 	}
 
 	return isDir
 }
 
+// IsFile reports whether the path is an existing regular file, i.e. not a directory,
+// device, pipe or socket. Symlinks are followed like Stat does.
+//
+// For a pure path, the filesystem is not checked and IsFile returns false.
+func (p *FsPath) IsFile() bool {
+	if p.pure {
+		return false
+	}
+
+	info, err := p.Stat()
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IsHidden reports whether the path is a hidden file or directory.
+//
+// On Unix-like systems a name starting with "." is hidden. On Windows, the hidden file
+// attribute is checked as well, which requires the path to exist on the OS file system.
+func (p *FsPath) IsHidden() bool {
+	return isHidden(p.absPath, p.Name)
+}
+
+// Size returns the size of the path in bytes.
+//
+// For a file, this is the length of its content. For a directory, it is the total size of all
+// regular files below it, computed by walking the tree; symlinks are not followed.
+//
+// Returns:
+//   - int64: The size in bytes.
+//   - error: An error if the path cannot be stat'ed or the directory cannot be walked.
+//
+// Example:
+//
+//	size, err := Path("/var/log").Size()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("logs use %d bytes\n", size)
+func (p *FsPath) Size() (int64, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	var total int64
+
+	err = p.Walk(func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// MustSize is like Size but panics on error.
+func (p *FsPath) MustSize() int64 {
+	size, err := p.Size()
+	p.e(err)
+
+	return size
+}
+
+// HasSuffix reports whether the path's Suffix matches any of the given suffixes, ignoring case.
+// A leading dot is added to candidates that lack one, so "jpg" and ".jpg" are equivalent.
+// Empty candidates are ignored, so it returns false when no suffixes are given.
+//
+// Example:
+//
+//	if Path("photo.JPG").HasSuffix(".jpg", ".png") {
+//	    // handle image
+//	}
+func (p *FsPath) HasSuffix(suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if suffix == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(suffix, ".") {
+			suffix = "." + suffix
+		}
+
+		if strings.EqualFold(p.Suffix, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Suffixes returns a list of the path's file extensions.
 func (p *FsPath) Suffixes() []string {
 	name := filepath.Base(p.absPath)
-	if name == "." || name == "/" {
+	if name == "." || name == string(filepath.Separator) {
 		return []string{}
 	}
 
@@ -201,6 +502,12 @@ func (p *FsPath) e(args ...interface{}) {
 	}
 }
 
+// isRoot reports whether path is a filesystem root: "/" on Unix, or a drive root
+// like `C:\` or a UNC share root like `\\host\share\` on Windows.
+func isRoot(path string) bool {
+	return filepath.Dir(path) == path
+}
+
 func parseFileName(name string) (stem, fullName, suffix string) {
 	fullName = name
 	suffix = filepath.Ext(name)
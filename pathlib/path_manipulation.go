@@ -1,9 +1,12 @@
 package pathlib
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 )
 
 // Home returns a new FsPath representing the user's home directory.
@@ -111,7 +114,7 @@ func (p *FsPath) Dir() *FsPath {
 	}
 
 	// For files, return the parent directory
-	return Path(filepath.Dir(p.absPath))
+	return p.newPath(filepath.Dir(p.absPath))
 }
 
 // Join joins one or more path components to the current path.
@@ -142,12 +145,77 @@ func (p *FsPath) Dir() *FsPath {
 func (p *FsPath) Join(others ...string) *FsPath {
 	if len(others) > 0 && filepath.IsAbs(others[0]) {
 		// If the first component is an absolute path, use it as the base
-		return Path(filepath.Join(others...))
+		return p.newPath(filepath.Join(others...))
 	}
 
 	components := append([]string{p.absPath}, others...)
 
-	return Path(filepath.Join(components...))
+	return p.newPath(filepath.Join(components...))
+}
+
+// Normalized returns a new FsPath for the lexically cleaned form of the path, as a pure string
+// operation: redundant separators are collapsed and "." and ".." elements are resolved with
+// filepath.Clean, and RawPath is set to the cleaned path.
+//
+// Path already cleans the path when it makes it absolute, but it also expands "~" and
+// environment variables and resolves relative paths against the current working directory.
+// Normalized does none of that: it never consults the working directory or the environment,
+// keeps the file system of the receiver, and keeps a pure path pure (and relative).
+//
+// Example:
+//
+//	p := Path("/var///log/../run/")
+//	fmt.Println(p.RawPath)              // "/var///log/../run/"
+//	fmt.Println(p.Normalized().RawPath) // "/var/run"
+func (p *FsPath) Normalized() *FsPath {
+	cleaned := filepath.Clean(p.absPath)
+	stem, name, suffix := parseFileName(filepath.Base(cleaned))
+
+	return &FsPath{
+		absPath: cleaned,
+		Stem:    stem,
+		Name:    name,
+		Suffix:  suffix,
+		RawPath: cleaned,
+		fs:      p.fs,
+		pure:    p.pure,
+	}
+}
+
+// SafeJoin joins an untrusted path fragment (e.g. an archive entry name or a user-supplied
+// file name) to the current path, guaranteeing the result stays within it.
+//
+// Both slash and OS-specific separators are accepted in untrusted.
+//
+// Parameters:
+//   - untrusted: The path fragment to join.
+//
+// Returns:
+//   - *FsPath: The joined path, inside the current path.
+//   - error: ErrIllegalFilePath if untrusted is absolute, carries a volume name, or
+//     escapes the current path via "..".
+//
+// Example:
+//
+//	uploads := Path("/srv/uploads")
+//	file, err := uploads.SafeJoin("alice/avatar.png")   // /srv/uploads/alice/avatar.png
+//	_, err = uploads.SafeJoin("../etc/passwd")          // ErrIllegalFilePath
+func (p *FsPath) SafeJoin(untrusted string) (*FsPath, error) {
+	fragment := filepath.FromSlash(untrusted)
+
+	if filepath.IsAbs(fragment) || filepath.VolumeName(fragment) != "" ||
+		strings.HasPrefix(fragment, string(filepath.Separator)) {
+		return nil, fmt.Errorf("%w: %s", ErrIllegalFilePath, untrusted)
+	}
+
+	joined := filepath.Join(p.absPath, fragment)
+
+	rel, err := filepath.Rel(p.absPath, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%w: %s", ErrIllegalFilePath, untrusted)
+	}
+
+	return p.newPath(joined), nil
 }
 
 // Parent returns the immediate parent directory path of the current path.
@@ -185,13 +253,13 @@ func (p *FsPath) Join(others ...string) *FsPath {
 //   - Quickly accessing the parent directory without specifying the number of levels to go up.
 //   - Simplifying code when only the immediate parent is needed.
 func (p *FsPath) Parent() *FsPath {
-	if p.absPath == "/" {
+	if isRoot(p.absPath) {
 		return p // Root directory is its own parent
 	}
 
 	parentPath := filepath.Dir(p.absPath)
 
-	return Path(parentPath)
+	return p.newPath(parentPath)
 }
 
 // Parents returns an iterator of this path's logical parents.
@@ -277,6 +345,7 @@ func (p *FsPath) ParentsUpTo(num int) *FsPath {
 // where each string is a component of the path.
 //
 // The function preserves the leading "/" if present in the original path.
+// On Windows the first element is the drive or UNC anchor, e.g. `C:\` or `\\host\share\`.
 //
 // Examples:
 //
@@ -292,6 +361,10 @@ func (p *FsPath) ParentsUpTo(num int) *FsPath {
 //	parts := path.Parts()
 //	// parts will be []string{"/"}
 //
+//	For a Windows path `C:\Users\me\file.txt`:
+//	parts := path.Parts()
+//	// parts will be []string{`C:\`, "Users", "me", "file.txt"}
+//
 // Note:
 //   - Trailing slashes are ignored.
 //   - Empty components (resulting from consecutive slashes) are omitted.
@@ -301,26 +374,24 @@ func (p *FsPath) Parts() []string {
 		return []string{}
 	}
 
-	// Trim trailing slashes
-	trimmed := strings.TrimRight(p.absPath, "/")
+	sep := string(filepath.Separator)
 
-	// If the path was just "/", return a slice with only "/"
-	if trimmed == "" {
-		return []string{"/"}
-	}
+	// Split off the volume name, e.g. "C:" or `\\host\share` on Windows, "" on Unix
+	volume := filepath.VolumeName(p.absPath)
+	rest := p.absPath[len(volume):]
 
-	// Split the path
-	parts := strings.Split(trimmed, "/")
+	var result []string
 
-	// If the original path started with a slash, add it as the first element
-	if strings.HasPrefix(p.absPath, "/") {
-		parts = append([]string{"/"}, parts...)
+	// If the path is rooted, the anchor (volume plus separator) is the first element
+	switch {
+	case strings.HasPrefix(rest, sep):
+		result = append(result, volume+sep)
+	case volume != "":
+		result = append(result, volume)
 	}
 
 	// Filter out empty parts
-	var result []string
-
-	for _, part := range parts {
+	for _, part := range strings.Split(rest, sep) {
 		if part != "" {
 			result = append(result, part)
 		}
@@ -403,7 +474,78 @@ func (p *FsPath) WithSuffix(suffix string) *FsPath {
 		suffix = "." + suffix
 	}
 
-	return Path(strings.TrimSuffix(p.absPath, p.Suffix) + suffix)
+	return p.newPath(strings.TrimSuffix(p.absPath, p.Suffix) + suffix)
+}
+
+// WithAllSuffixesReplaced returns a new FsPath with every suffix reported by Suffixes replaced
+// by newSuffix. A leading dot is added to newSuffix if missing; an empty newSuffix removes
+// all suffixes.
+//
+// Example:
+//
+//	p := Path("/backups/archive.tar.gz")
+//	fmt.Println(p.WithAllSuffixesReplaced(".zip").Name) // "archive.zip"
+//	fmt.Println(p.WithSuffix(".zip").Name)             // "archive.tar.zip"
+func (p *FsPath) WithAllSuffixesReplaced(newSuffix string) *FsPath {
+	if newSuffix != "" && !strings.HasPrefix(newSuffix, ".") {
+		newSuffix = "." + newSuffix
+	}
+
+	name := filepath.Base(p.absPath)
+	base := strings.TrimSuffix(name, strings.Join(p.Suffixes(), ""))
+
+	return p.WithName(base + newSuffix)
+}
+
+// WithoutSuffixes returns a new FsPath with all suffixes removed, e.g. "archive.tar.gz" becomes "archive".
+// Hidden files keep their leading dot, so ".config.json" becomes ".config".
+func (p *FsPath) WithoutSuffixes() *FsPath {
+	return p.WithAllSuffixesReplaced("")
+}
+
+// namePlaceholder matches the placeholders of ExpandName, e.g. "{stem}".
+var namePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// ExpandName returns a new FsPath built from a name template, relative to the parent directory
+// of the current path, for renames that WithStem and WithSuffix cannot express alone.
+//
+// Supported placeholders:
+//   - {stem}: The Stem, e.g. "photo" for "photo.jpg".
+//   - {name}: The Name, e.g. "photo.jpg".
+//   - {suffix}: The Suffix including the dot, e.g. ".jpg".
+//   - {parent}: The name of the parent directory.
+//   - {date}: The current local date as "2006-01-02".
+//
+// Unknown placeholders are left in the result literally, so a typo such as "{stme}" shows
+// up in the file name instead of silently disappearing. The template may contain separators
+// to produce a path in a subdirectory.
+//
+// Example:
+//
+//	p := Path("/photos/cat.jpg")
+//	p.ExpandName("{stem}_thumb{suffix}") // "/photos/cat_thumb.jpg"
+//	p.ExpandName("{date}/{name}")        // "/photos/2024-05-01/cat.jpg"
+func (p *FsPath) ExpandName(template string) *FsPath {
+	values := map[string]string{
+		"stem":   p.Stem,
+		"name":   p.Name,
+		"suffix": p.Suffix,
+		"parent": filepath.Base(filepath.Dir(p.absPath)),
+		"date":   time.Now().Format(time.DateOnly),
+	}
+
+	expanded := namePlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := values[match[1:len(match)-1]]; ok {
+			return value
+		}
+
+		return match
+	})
+
+	expandedPath := p.newPath(filepath.Join(filepath.Dir(p.absPath), expanded))
+	expandedPath.fs = p.fs
+
+	return expandedPath
 }
 
 // WithRenamedParentDir creates a new FSPath with the parent directory renamed.
@@ -449,7 +591,7 @@ func (p *FsPath) WithSuffix(suffix string) *FsPath {
 //	// newPath now represents "/tmp/a/c/file.txt"
 func (p *FsPath) WithRenamedParentDir(newParentName string) *FsPath {
 	// If the current path is the root directory, return the original FSPath
-	if p.absPath == "/" {
+	if isRoot(p.absPath) {
 		return p
 	}
 
@@ -512,7 +654,7 @@ func (p *FsPath) WithSuffixAndSuffixedParentDir(newSuffix string) *FsPath {
 	dirSuffix := strings.TrimPrefix(newSuffix, ".")
 
 	// Handle root directory case
-	if p.Parent().absPath == "/" {
+	if isRoot(p.Parent().absPath) {
 		return p.Parent().Join("_"+dirSuffix, newPath.Name)
 	}
 
@@ -538,7 +680,7 @@ func (p *FsPath) WithReplacedDirAndSuffix(dirName, newSuffix string) *FsPath {
 	newPath := p.WithSuffix(newSuffix)
 
 	// Handle root directory case
-	if p.Parent().absPath == "/" {
+	if isRoot(p.Parent().absPath) {
 		return p.Parent().Join(dirName, newPath.Name)
 	}
 
@@ -285,3 +285,20 @@ func (s *PathManipulationSuite) TestLastSegment() {
 		})
 	}
 }
+
+func (s *PathManipulationSuite) TestSafeJoin() {
+	base := Path("/srv/uploads")
+
+	joined, err := base.SafeJoin("sub/file")
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(base.String(), "sub", "file"), joined.String())
+
+	joined, err = base.SafeJoin("sub/../other.txt")
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(base.String(), "other.txt"), joined.String())
+
+	for _, untrusted := range []string{"../etc/passwd", "/etc/passwd", "sub/../../escape", ".."} {
+		_, err := base.SafeJoin(untrusted)
+		s.Require().ErrorIs(err, ErrIllegalFilePath, untrusted)
+	}
+}
@@ -285,6 +285,36 @@ func (s *PathSuite) TestExists() {
 	}
 }
 
+// permissionDeniedFs fails every Stat with a permission error, like a path below an unsearchable directory.
+type permissionDeniedFs struct {
+	afero.Fs
+}
+
+func (permissionDeniedFs) Stat(name string) (os.FileInfo, error) {
+	return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrPermission}
+}
+
+func (s *PathSuite) TestExistsErr() {
+	exists, err := Path(s.createTempFile("existing.txt", "content")).ExistsErr()
+	s.Require().NoError(err)
+	s.True(exists)
+
+	exists, err = Path(filepath.Join(s.tempDir, "missing.txt")).ExistsErr()
+	s.Require().NoError(err)
+	s.False(exists)
+
+	locked := Path(filepath.Join(s.tempDir, "locked", "file.txt"))
+	locked.fs = permissionDeniedFs{afero.NewOsFs()}
+
+	exists, err = locked.ExistsErr()
+	s.Require().ErrorIs(err, os.ErrPermission)
+	s.False(exists)
+	s.True(locked.Exists(), "a permission error doesn't mean the path is missing")
+
+	// a file used as a directory fails with ENOTDIR on Unix
+	s.False(Path(filepath.Join(s.createTempFile("plain.txt", "content"), "child")).Exists())
+}
+
 func (s *PathSuite) TestIsDir() {
 	file := s.createTempFile("file.txt", "content")
 
@@ -305,6 +335,156 @@ func (s *PathSuite) TestIsDir() {
 	}
 }
 
+// statCountingFs counts the Stat calls made on the wrapped afero.Fs.
+type statCountingFs struct {
+	afero.Fs
+	stats int
+}
+
+func (fs *statCountingFs) Stat(name string) (os.FileInfo, error) {
+	fs.stats++
+	return fs.Fs.Stat(name)
+}
+
+func (s *PathSuite) TestStatCache() {
+	counting := &statCountingFs{Fs: afero.NewOsFs()}
+
+	file := Path(s.createTempFile("cached.txt", "content"))
+	file.fs = counting
+
+	s.True(file.Exists())
+	s.True(file.Exists())
+	s.Equal(2, counting.stats, "uncached by default")
+
+	counting.stats = 0
+	file.StatCache()
+
+	s.True(file.Exists())
+	s.True(file.Exists())
+	s.False(file.IsDir())
+	s.Equal(1, counting.stats)
+
+	s.Require().NoError(os.Remove(file.String()))
+	s.True(file.Exists(), "stale until invalidated")
+
+	file.Invalidate()
+	s.False(file.Exists())
+	s.False(file.Exists())
+	s.Equal(2, counting.stats, "errors are cached too")
+}
+
+func (s *PathSuite) TestIsFile() {
+	file := s.createTempFile("file.txt", "content")
+	s.Require().NoError(os.Symlink(file, filepath.Join(s.tempDir, "link.txt")))
+	s.Require().NoError(os.Symlink(s.tempDir, filepath.Join(s.tempDir, "link_dir")))
+
+	tests := []struct {
+		name     string
+		path     string
+		expected bool
+	}{
+		{"regular file", file, true},
+		{"symlink to file", filepath.Join(s.tempDir, "link.txt"), true},
+		{"directory", s.tempDir, false},
+		{"symlink to directory", filepath.Join(s.tempDir, "link_dir"), false},
+		{"non-existing", filepath.Join(s.tempDir, "missing.txt"), false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, Path(tt.path).IsFile())
+		})
+	}
+
+	s.False(PurePath("file.txt").IsFile())
+}
+
+func (s *PathSuite) TestIsHidden() {
+	s.True(Path(s.createTempFile(".env", "")).IsHidden())
+	s.True(Path(filepath.Join(s.tempDir, ".git", "config")).Parent().IsHidden())
+	s.False(Path(s.createTempFile("visible.txt", "")).IsHidden())
+	s.False(Path(s.createTempFile("file.with.dots", "")).IsHidden())
+}
+
+func (s *PathSuite) TestSize() {
+	file := Path(s.createTempFile("sized.txt", _testContent))
+
+	size, err := file.Size()
+	s.Require().NoError(err)
+	s.Equal(int64(len(_testContent)), size)
+
+	dir := Path(s.tempDir).Join("sized")
+	s.Require().NoError(dir.Join("a.txt").WriteText("abc"))
+	s.Require().NoError(dir.Join("sub", "b.txt").WriteText("de"))
+	s.Equal(int64(5), dir.MustSize())
+
+	_, err = Path(s.tempDir).Join("missing.txt").Size()
+	s.ErrorIs(err, os.ErrNotExist)
+	s.Panics(func() { Path(s.tempDir).Join("missing.txt").MustSize() })
+}
+
+func (s *PathSuite) TestClone() {
+	file := Path(s.createTempFile("clone.txt", _testContent)).StatCache()
+	_, err := file.Stat()
+	s.Require().NoError(err)
+
+	clone := file.Clone()
+	s.Equal(file.String(), clone.String())
+	s.Equal(file.Fs(), clone.Fs())
+
+	clone.Name = "changed.txt"
+	s.Equal("clone.txt", file.Name)
+
+	s.Require().NoError(file.Unlink(false))
+	file.Invalidate()
+	s.False(file.Exists())
+	s.True(clone.Exists(), "clone should keep its own cached Stat result")
+}
+
+func (s *PathSuite) TestWithFs() {
+	memFs := afero.NewMemMapFs()
+	file := Path(filepath.Join(s.tempDir, "mem.txt")).WithFs(memFs)
+
+	s.Equal(memFs, file.Fs())
+	s.Require().NoError(file.WriteText(_testContent))
+
+	exists, err := afero.Exists(memFs, file.String())
+	s.Require().NoError(err)
+	s.True(exists)
+	s.NoFileExists(file.String())
+
+	osFile := Path(file.String())
+	s.False(osFile.Exists())
+	s.Equal(_testContent, osFile.WithFs(memFs).MustReadText())
+}
+
+func (s *PathSuite) TestHasSuffix() {
+	photo := Path("/photos/photo.JPG")
+	s.True(photo.HasSuffix(".jpg", ".png"))
+	s.True(photo.HasSuffix("jpg"))
+	s.False(photo.HasSuffix(".png", ".gif"))
+	s.False(photo.HasSuffix())
+	s.False(Path("/photos/README").HasSuffix(".jpg"))
+	s.False(Path("/photos/README").HasSuffix(""), "an empty suffix does not match a file without extension")
+}
+
+func (s *PathSuite) TestNormalized() {
+	p := Path("/var///log/../run/")
+	normalized := p.Normalized()
+	s.Equal("/var/run", normalized.String())
+	s.Equal("/var/run", normalized.RawPath)
+	s.Equal("run", normalized.Name)
+	s.Equal("/var///log/../run/", p.RawPath, "the receiver is not modified")
+
+	pure := &FsPath{absPath: "a//b/./../c/$HOME", pure: true, fs: p.fs}
+	normalized = pure.Normalized()
+	s.Equal("a/c/$HOME", normalized.String(), "no cwd resolution or env expansion")
+	s.True(normalized.IsPure())
+
+	memFs := afero.NewMemMapFs()
+	s.Equal(memFs, Path("/tmp//x").WithFs(memFs).Normalized().Fs())
+}
+
 func (s *PathSuite) TestMkParentDir() {
 	path := filepath.Join(s.tempDir, "new", "parent", "dir", "file.txt")
 	file := Path(path)
@@ -1034,6 +1214,50 @@ func (s *PathSuite) TestWithSuffix() {
 	}
 }
 
+func (s *PathSuite) TestWithAllSuffixesReplaced() {
+	tests := []struct {
+		name      string
+		path      string
+		newSuffix string
+		expected  string
+	}{
+		{"replace multiple suffixes", "/backups/archive.tar.gz", ".zip", "/backups/archive.zip"},
+		{"replace without dot", "/backups/archive.tar.gz", "zip", "/backups/archive.zip"},
+		{"remove all suffixes", "/backups/archive.tar.gz", "", "/backups/archive"},
+		{"single suffix", "/home/user/file.txt", ".md", "/home/user/file.md"},
+		{"no suffix", "/home/user/README", ".md", "/home/user/README.md"},
+		{"hidden file", "/home/user/.config.json", ".yaml", "/home/user/.config.yaml"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, Path(tt.path).WithAllSuffixesReplaced(tt.newSuffix).absPath)
+		})
+	}
+
+	s.Equal("archive", Path("/backups/archive.tar.gz").WithoutSuffixes().Name)
+	s.Equal(".bashrc", Path("/home/user/.bashrc").WithoutSuffixes().Name)
+}
+
+func (s *PathSuite) TestExpandName() {
+	p := Path("/photos/cats/cat.jpg")
+	today := time.Now().Format(time.DateOnly)
+
+	s.Equal("/photos/cats/cat_thumb.jpg", p.ExpandName("{stem}_thumb{suffix}").String())
+	s.Equal("/photos/cats/"+today+"/cat.jpg", p.ExpandName("{date}/{name}").String())
+	s.Equal("/photos/cats/cats-cat.png", p.ExpandName("{parent}-{stem}.png").String())
+	s.Equal("/photos/cats/{unknown}_cat.jpg", p.ExpandName("{unknown}_{name}").String())
+
+	// the result stays on the same file system
+	memFs := afero.NewMemMapFs()
+	s.Same(memFs, p.WithFs(memFs).ExpandName("{stem}_thumb{suffix}").Fs())
+
+	// a pure path is not resolved against the working directory
+	pure := PurePath(filepath.Join("photos", "cat.jpg")).ExpandName("{stem}_thumb{suffix}")
+	s.True(pure.IsPure())
+	s.Equal(filepath.Join("photos", "cat_thumb.jpg"), pure.String())
+}
+
 func (s *PathSuite) TestWithRenamedParentDir() {
 	tests := []struct {
 		name       string
@@ -1231,6 +1455,38 @@ func (s *PathSuite) TestChmod() {
 	s.Require().Error(err)
 }
 
+func (s *PathSuite) TestChmodRecursive() {
+	root := filepath.Join(s.tempDir, "extracted")
+	s.Require().NoError(os.MkdirAll(filepath.Join(root, "sub", "deep"), 0o700))
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "top.txt"), []byte("x"), _mode600))
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "sub", "deep", "inner.txt"), []byte("x"), 0o700))
+
+	target := filepath.Join(s.tempDir, "outside.txt")
+	s.Require().NoError(os.WriteFile(target, []byte("x"), _mode600))
+	s.Require().NoError(os.Symlink(target, filepath.Join(root, "link")))
+
+	s.Require().NoError(Path(root).ChmodRecursive(_mode644, 0o755))
+
+	for _, dir := range []string{root, filepath.Join(root, "sub"), filepath.Join(root, "sub", "deep")} {
+		info, err := os.Stat(dir)
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(0o755), info.Mode().Perm(), dir)
+	}
+
+	for _, file := range []string{filepath.Join(root, "top.txt"), filepath.Join(root, "sub", "deep", "inner.txt")} {
+		info, err := os.Stat(file)
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(_mode644), info.Mode().Perm(), file)
+	}
+
+	// the symlink target is left alone
+	info, err := os.Stat(target)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(_mode600), info.Mode().Perm())
+
+	s.Error(Path(filepath.Join(s.tempDir, "missing")).ChmodRecursive(_mode644, 0o755))
+}
+
 func (s *PathSuite) TestUnlink() {
 	s.T().Parallel()
 
@@ -1344,3 +1600,32 @@ func (s *PathSuite) Test00Manual() {
 	p := Path(raw)
 	s.NotNil(p)
 }
+
+func (s *PathSuite) TestPurePath() {
+	pure := PurePath("a/b/../c")
+	s.True(pure.IsPure())
+	s.Equal("a/c", pure.String())
+	s.Equal("c", pure.Name)
+
+	s.Equal("a/c/d.txt", pure.Join("d.txt").String())
+	s.True(pure.Join("d.txt").IsPure())
+	s.Equal("a", pure.Parent().String())
+	s.Equal("a/x.txt", pure.WithName("x.txt").String())
+	s.Equal("a/c.json", pure.WithSuffix("json").String())
+	s.Equal([]string{"a", "c"}, pure.Parts())
+
+	s.Equal(".", PurePath("").String())
+	s.Equal("/b", PurePath("/a/../b").String())
+
+	// the result does not depend on the working directory
+	cwd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.Require().NoError(os.Chdir(s.tempDir))
+
+	defer func() {
+		s.Require().NoError(os.Chdir(cwd))
+	}()
+
+	s.Equal("a/c", PurePath("a/b/../c").String())
+	s.False(Path("a").IsPure())
+}
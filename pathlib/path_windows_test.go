@@ -0,0 +1,33 @@
+//go:build windows
+
+package pathlib
+
+func (s *PathSuite) TestWindowsDriveLetterPath() {
+	file := Path(`C:\Users\me\file.txt`)
+
+	s.Equal(`C:\Users\me\file.txt`, file.String())
+	s.Equal("file.txt", file.Name)
+	s.Equal("file", file.Stem)
+	s.Equal(".txt", file.Suffix)
+	s.Equal([]string{`C:\`, "Users", "me", "file.txt"}, file.Parts())
+	s.Equal(`C:\Users\me`, file.Parent().String())
+
+	root := Path(`C:\`)
+	s.Equal(`C:\`, root.Parent().String())
+	s.Equal([]string{`C:\`}, root.Parts())
+	s.Equal(root.String(), root.WithRenamedParentDir("x").String())
+
+	s.True(Path(`C:\Users\me\`).IsDir())
+	s.Equal(`C:\_json\file.json`, Path(`C:\file.txt`).WithSuffixAndSuffixedParentDir(".json").String())
+}
+
+func (s *PathSuite) TestWindowsUNCPath() {
+	file := Path(`\\server\share\dir\file.txt`)
+
+	s.Equal("file.txt", file.Name)
+	s.Equal([]string{`\\server\share\`, "dir", "file.txt"}, file.Parts())
+	s.Equal(`\\server\share\dir`, file.Parent().String())
+
+	root := Path(`\\server\share\`)
+	s.Equal(root.String(), root.Parent().String())
+}
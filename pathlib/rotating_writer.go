@@ -0,0 +1,199 @@
+package pathlib
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+var ErrWriterClosed = errors.New("writer is closed")
+
+// RotatingWriter appends lines to a file and rotates it once it holds a given number of lines.
+//
+// When the current file is full, it is renamed to a numbered sibling
+// ("events.log" becomes "events.1.log", then "events.2.log", ...) and a fresh file is started
+// at the original path. Writes are buffered; call Close to flush them.
+//
+// Example usage:
+//
+//	w := NewRotatingWriter(Path("/var/log/events.log"), 10000)
+//	defer w.Close()
+//
+//	if err := w.WriteLine(`{"event":"start"}`); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// A RotatingWriter is safe for concurrent use.
+type RotatingWriter struct {
+	mu sync.Mutex
+
+	path     *FsPath
+	maxLines int
+
+	file    afero.File
+	buf     *bufio.Writer
+	lines   int
+	nextNum int
+	closed  bool
+}
+
+// NewRotatingWriter creates a RotatingWriter for p that rotates every maxLines lines.
+//
+// The file is opened lazily on the first write, and its current line count is
+// initialized from the existing content, so appending to a partially filled file
+// continues the count. A maxLines of zero or less disables rotation.
+func NewRotatingWriter(p *FsPath, maxLines int) *RotatingWriter {
+	return &RotatingWriter{
+		path:     p,
+		maxLines: maxLines,
+		nextNum:  1,
+	}
+}
+
+// WriteLine appends s followed by a newline, rotating the file first if it is full.
+func (w *RotatingWriter) WriteLine(s string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	if w.maxLines > 0 && w.lines >= w.maxLines {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.buf.WriteString(s + "\n"); err != nil {
+		return err
+	}
+
+	w.lines++
+
+	return nil
+}
+
+// Close flushes buffered lines and closes the current file.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	return w.closeFile()
+}
+
+func (w *RotatingWriter) open() error {
+	lines, err := countLines(w.path)
+	if err != nil {
+		return err
+	}
+
+	if err := w.path.MkParentDir(); err != nil {
+		return err
+	}
+
+	file, err := w.path.fs.OpenFile(w.path.absPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FileMode644)
+	if err != nil {
+		return err
+	}
+
+	audit(AuditWrite, w.path.absPath)
+
+	w.file = file
+	w.buf = bufio.NewWriter(file)
+	w.lines = lines
+
+	return nil
+}
+
+func (w *RotatingWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+
+	flushErr := w.buf.Flush()
+	closeErr := w.file.Close()
+	w.file, w.buf = nil, nil
+
+	if flushErr != nil {
+		return flushErr
+	}
+
+	return closeErr
+}
+
+func (w *RotatingWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	target := w.nextRotatedPath()
+	if err := w.path.Rename(target.absPath); err != nil {
+		return fmt.Errorf("failed to rotate %s: %w", w.path.absPath, err)
+	}
+
+	return w.open()
+}
+
+// nextRotatedPath returns the first numbered sibling that doesn't exist yet.
+func (w *RotatingWriter) nextRotatedPath() *FsPath {
+	for {
+		name := w.path.Stem + "." + strconv.Itoa(w.nextNum) + w.path.Suffix
+		w.nextNum++
+
+		target := w.path.withSameFs(w.path.Parent().Join(name).absPath)
+		if !target.Exists() {
+			return target
+		}
+	}
+}
+
+// countLines counts the newline-terminated lines of the file, returning 0 if it doesn't exist.
+func countLines(p *FsPath) (int, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+	defer file.Close()
+
+	const chunkSize = 32 * 1024
+
+	count := 0
+	buf := make([]byte, chunkSize)
+
+	for {
+		n, err := file.Read(buf)
+		count += bytes.Count(buf[:n], []byte{'\n'})
+
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package pathlib
+
+import (
+	"strconv"
+)
+
+func (s *PathSuite) TestRotatingWriter() {
+	file := Path(s.tempDir).Join("logs", "events.log")
+
+	const maxLines = 3
+
+	w := NewRotatingWriter(file, maxLines)
+	for i := 0; i < 2*maxLines; i++ {
+		s.Require().NoError(w.WriteLine("line" + strconv.Itoa(i)))
+	}
+
+	s.Require().NoError(w.Close())
+	s.ErrorIs(w.WriteLine("late"), ErrWriterClosed)
+
+	names, err := file.ListFileNamesWithGlob("*")
+	s.Require().NoError(err)
+	s.Equal([]string{"events.1.log", "events.log"}, names)
+
+	s.Equal([]string{"line0", "line1", "line2"}, file.Parent().Join("events.1.log").MustGetLines())
+	s.Equal([]string{"line3", "line4", "line5"}, file.MustGetLines())
+}
+
+func (s *PathSuite) TestRotatingWriterResumesCount() {
+	file := Path(s.createTempFile("events.log", "old1\nold2\n"))
+
+	w := NewRotatingWriter(file, 3)
+	s.Require().NoError(w.WriteLine("new1"))
+	s.Require().NoError(w.WriteLine("new2"))
+	s.Require().NoError(w.Close())
+
+	s.Equal([]string{"new2"}, file.MustGetLines())
+	s.Equal([]string{"old1", "old2", "new1"}, file.Parent().Join("events.1.log").MustGetLines())
+}
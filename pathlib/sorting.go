@@ -0,0 +1,115 @@
+package pathlib
+
+import (
+	"io/fs"
+	"sort"
+)
+
+// SortByName sorts paths in place by their base name, using the absolute path
+// to break ties between equal names.
+//
+// Example:
+//
+//	files := []*FsPath{Path("b.txt"), Path("a.txt")}
+//	SortByName(files, false)
+//	// files is now [a.txt, b.txt]
+func SortByName(paths []*FsPath, descending bool) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		a, b := paths[i], paths[j]
+		if descending {
+			a, b = b, a
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.absPath < b.absPath
+	})
+}
+
+// SortBySize sorts paths in place by file size, smallest first unless descending is true.
+//
+// Each path is stat'ed exactly once. Paths that cannot be stat'ed (e.g. missing files)
+// are moved to the end in both directions, ordered by name.
+//
+// Example:
+//
+//	files := []*FsPath{Path("small.txt"), Path("big.bin")}
+//	SortBySize(files, true)
+//	// files is now [big.bin, small.txt]
+func SortBySize(paths []*FsPath, descending bool) {
+	sortByStat(paths, descending, func(a, b fs.FileInfo) int {
+		return compareInt64(a.Size(), b.Size())
+	})
+}
+
+// SortByModTime sorts paths in place by modification time, oldest first unless descending is true.
+//
+// Each path is stat'ed exactly once. Paths that cannot be stat'ed (e.g. missing files)
+// are moved to the end in both directions, ordered by name.
+func SortByModTime(paths []*FsPath, descending bool) {
+	sortByStat(paths, descending, func(a, b fs.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+}
+
+// statEntry pairs a path with its cached Stat result for sorting.
+type statEntry struct {
+	path *FsPath
+	info fs.FileInfo
+}
+
+// sortByStat sorts paths by compare applied to their cached file info, falling back to the
+// path name for equal values. Entries whose Stat failed always sort last.
+func sortByStat(paths []*FsPath, descending bool, compare func(a, b fs.FileInfo) int) {
+	entries := make([]statEntry, len(paths))
+
+	for i, p := range paths {
+		info, err := p.Stat()
+		if err != nil {
+			info = nil
+		}
+
+		entries[i] = statEntry{path: p, info: info}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+
+		switch {
+		case a.info == nil && b.info == nil:
+			return a.path.absPath < b.path.absPath
+		case a.info == nil:
+			return false
+		case b.info == nil:
+			return true
+		}
+
+		result := compare(a.info, b.info)
+		if descending {
+			result = -result
+		}
+
+		if result != 0 {
+			return result < 0
+		}
+
+		return a.path.absPath < b.path.absPath
+	})
+
+	for i, entry := range entries {
+		paths[i] = entry.path
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,66 @@
+package pathlib
+
+import (
+	"os"
+	"time"
+)
+
+func (s *PathSuite) sortingFixtures() []*FsPath {
+	root := Path(s.tempDir)
+
+	small := root.Join("small.txt")
+	s.Require().NoError(small.WriteText("a"))
+
+	large := root.Join("large.txt")
+	s.Require().NoError(large.WriteText("aaaaaaaaaa"))
+
+	medium := root.Join("medium.txt")
+	s.Require().NoError(medium.WriteText("aaaaa"))
+
+	// small is the oldest file, medium the newest
+	now := time.Now()
+	s.Require().NoError(os.Chtimes(small.String(), now, now.Add(-2*time.Hour)))
+	s.Require().NoError(os.Chtimes(large.String(), now, now.Add(-time.Hour)))
+	s.Require().NoError(os.Chtimes(medium.String(), now, now))
+
+	return []*FsPath{medium, root.Join("missing.txt"), small, large}
+}
+
+func names(paths []*FsPath) []string {
+	result := make([]string, len(paths))
+	for i, p := range paths {
+		result[i] = p.Name
+	}
+
+	return result
+}
+
+func (s *PathSuite) TestSortBySize() {
+	paths := s.sortingFixtures()
+
+	SortBySize(paths, true)
+	s.Equal([]string{"large.txt", "medium.txt", "small.txt", "missing.txt"}, names(paths))
+
+	SortBySize(paths, false)
+	s.Equal([]string{"small.txt", "medium.txt", "large.txt", "missing.txt"}, names(paths))
+}
+
+func (s *PathSuite) TestSortByModTime() {
+	paths := s.sortingFixtures()
+
+	SortByModTime(paths, false)
+	s.Equal([]string{"small.txt", "large.txt", "medium.txt", "missing.txt"}, names(paths))
+
+	SortByModTime(paths, true)
+	s.Equal([]string{"medium.txt", "large.txt", "small.txt", "missing.txt"}, names(paths))
+}
+
+func (s *PathSuite) TestSortByName() {
+	paths := s.sortingFixtures()
+
+	SortByName(paths, false)
+	s.Equal([]string{"large.txt", "medium.txt", "missing.txt", "small.txt"}, names(paths))
+
+	SortByName(paths, true)
+	s.Equal([]string{"small.txt", "missing.txt", "medium.txt", "large.txt"}, names(paths))
+}
@@ -0,0 +1,9 @@
+package pathlib
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func renameExchange(oldPath, newPath string) error {
+	return unix.Renameat2(unix.AT_FDCWD, oldPath, unix.AT_FDCWD, newPath, unix.RENAME_EXCHANGE)
+}
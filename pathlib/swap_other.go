@@ -0,0 +1,7 @@
+//go:build !linux
+
+package pathlib
+
+func renameExchange(string, string) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,148 @@
+package pathlib
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// textSampleSize is the number of leading bytes IsText inspects.
+const textSampleSize = 8 * 1024
+
+// IsText reports whether the file looks like text, by inspecting its first 8KB with a heuristic
+// similar to git's: a NUL byte means binary, otherwise the sample is text if it's valid UTF-8
+// or if control characters make up less than 1/128 of the printable bytes, which accepts
+// legacy 8-bit encodings like Latin-1. An empty file is text.
+//
+// Returns:
+//   - bool: true if the file looks like text.
+//   - error: An error if the file cannot be opened or read.
+//
+// Example usage:
+//
+//	isText, err := Path("unknown.dat").IsText()
+//	if err == nil && isText {
+//	    content, _ := Path("unknown.dat").GetString()
+//	    process(content)
+//	}
+func (p *FsPath) IsText() (bool, error) {
+	sample, err := p.readHead(textSampleSize)
+	if err != nil {
+		return false, err
+	}
+
+	return looksLikeText(sample, len(sample) == textSampleSize), nil
+}
+
+// DetectLineEnding returns the line ending used by the file, based on its first line break:
+// "\r\n" for Windows-style and "\n" for Unix-style line endings, or "" if the file has no
+// line break. Only the first line is read.
+//
+// Returns:
+//   - string: "\n", "\r\n" or "".
+//   - error: An error if the file cannot be opened or read.
+//
+// Example usage:
+//
+//	eol, err := Path("data.csv").DetectLineEnding()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	output := strings.Join(lines, eol)
+func (p *FsPath) DetectLineEnding() (string, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	prev := byte(0)
+
+	for {
+		b, err := reader.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return "", nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if b == '\n' {
+			if prev == '\r' {
+				return "\r\n", nil
+			}
+
+			return "\n", nil
+		}
+
+		prev = b
+	}
+}
+
+// readHead returns up to n leading bytes of the file.
+func (p *FsPath) readHead(n int) ([]byte, error) {
+	file, err := p.fs.Open(p.absPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, n)
+
+	read, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	return buf[:read], nil
+}
+
+// looksLikeText classifies sample as text or binary. truncated tells that the sample may end
+// in the middle of a UTF-8 sequence, which is then ignored.
+func looksLikeText(sample []byte, truncated bool) bool {
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return false
+	}
+
+	if truncated {
+		sample = trimPartialRune(sample)
+	}
+
+	if utf8.Valid(sample) {
+		return true
+	}
+
+	printable, nonPrintable := 0, 0
+
+	for _, b := range sample {
+		switch {
+		case b == 0x7f:
+			nonPrintable++
+		case b >= 0x20, b == '\t', b == '\n', b == '\r', b == '\f', b == '\b', b == 0x1b:
+			printable++
+		default:
+			nonPrintable++
+		}
+	}
+
+	return nonPrintable <= printable/128
+}
+
+// trimPartialRune removes an incomplete UTF-8 sequence from the end of b.
+func trimPartialRune(b []byte) []byte {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return b[:i]
+			}
+
+			break
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,55 @@
+package pathlib
+
+import (
+	"strings"
+)
+
+func (s *PathSuite) TestIsText() {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"ascii", "hello\nworld\n", true},
+		{"utf8", "héllo wörld, 你好 🌍\n", true},
+		{"empty", "", true},
+		{"latin1", "caf\xe9 cr\xe8me br\xfbl\xe9e\n", true},
+		{"nul bytes", "PK\x03\x04\x00\x00binary", false},
+		{"control bytes", strings.Repeat("\x01\x02\x03\xff", 100), false},
+		// a multi-byte rune cut by the 8KB sample boundary is still text
+		{"truncated rune", strings.Repeat("a", textSampleSize-1) + "你", true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			isText, err := Path(s.createTempFile(tt.name+".dat", tt.content)).IsText()
+			s.Require().NoError(err)
+			s.Equal(tt.want, isText)
+		})
+	}
+
+	_, err := Path(s.tempDir + "/missing.dat").IsText()
+	s.Error(err)
+}
+
+func (s *PathSuite) TestDetectLineEnding() {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unix", "a\nb\r\n", "\n"},
+		{"windows", "a\r\nb\n", "\r\n"},
+		{"none", "single line", ""},
+		{"empty", "", ""},
+		{"long first line", strings.Repeat("x", 10000) + "\r\n", "\r\n"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eol, err := Path(s.createTempFile(tt.name+".txt", tt.content)).DetectLineEnding()
+			s.Require().NoError(err)
+			s.Equal(tt.want, eol)
+		})
+	}
+}
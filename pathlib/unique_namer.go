@@ -0,0 +1,67 @@
+package pathlib
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// UniqueNamer hands out unique file names within a directory, e.g. for a batch of downloads
+// that may share the same name. It remembers the names it has already returned, so names
+// stay unique even before the files are written.
+//
+// A UniqueNamer is safe for concurrent use.
+type UniqueNamer struct {
+	dir *FsPath
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+// NewUniqueNamer creates a UniqueNamer allocating names in dir.
+//
+// Example:
+//
+//	namer := NewUniqueNamer(Path("/tmp/downloads"))
+//	for _, url := range urls {
+//	    target := namer.Next(path.Base(url)) // image.jpg, image (1).jpg, ...
+//	    go download(url, target)
+//	}
+func NewUniqueNamer(dir *FsPath) *UniqueNamer {
+	return &UniqueNamer{
+		dir:  dir,
+		used: make(map[string]bool),
+	}
+}
+
+// Next returns a path in the namer's directory for the desired name. If the name already exists
+// on disk or was handed out before, "name (1).ext", "name (2).ext", ... is tried instead.
+func (n *UniqueNamer) Next(name string) *FsPath {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	unique := uniqueName(name, func(candidate string) bool {
+		return n.used[candidate] || n.dir.Join(candidate).Exists()
+	})
+	n.used[unique] = true
+
+	return n.dir.Join(unique)
+}
+
+// uniqueName returns name if it is not taken, or else the first "base (n).ext" variant that is not.
+func uniqueName(name string, taken func(candidate string) bool) string {
+	if !taken(name) {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
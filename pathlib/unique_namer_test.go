@@ -0,0 +1,48 @@
+package pathlib
+
+import (
+	"sync"
+)
+
+func (s *PathSuite) TestUniqueNamer() {
+	dir := Path(s.tempDir)
+	namer := NewUniqueNamer(dir)
+
+	s.Equal("a.txt", namer.Next("a.txt").Name)
+	s.Equal("a (1).txt", namer.Next("a.txt").Name)
+	s.Equal("a (2).txt", namer.Next("a.txt").Name)
+
+	// names existing on disk are skipped too
+	s.createTempFile("b.txt", "")
+	s.createTempFile("b (1).txt", "")
+	s.Equal("b (2).txt", NewUniqueNamer(dir).Next("b.txt").Name)
+	s.Equal("README", namer.Next("README").Name)
+	s.Equal("README (1)", namer.Next("README").Name)
+}
+
+func (s *PathSuite) TestUniqueNamerConcurrent() {
+	namer := NewUniqueNamer(Path(s.tempDir))
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		names = make(map[string]bool)
+	)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			name := namer.Next("image.jpg").Name
+
+			mu.Lock()
+			names[name] = true
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	s.Len(names, 50)
+}
@@ -76,6 +76,33 @@ func Cwd() (*FsPath, error) {
 	return Path(dir), nil
 }
 
+// NewTempDir creates a new temporary directory and returns it with a cleanup function.
+//
+// The directory is created in the default temp directory (see os.TempDir) with a name
+// starting with prefix. The returned cleanup function removes the directory and everything
+// in it; it ignores a directory that no longer exists, so calling it more than once is safe.
+//
+// Example:
+//
+//	dir, cleanup, err := NewTempDir("scratch-")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer cleanup()
+func NewTempDir(prefix string) (*FsPath, func(), error) {
+	dir, err := os.MkdirTemp("", prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmp := Path(dir)
+	cleanup := func() {
+		_ = tmp.RmTree()
+	}
+
+	return tmp, cleanup, nil
+}
+
 // Expand takes a file path and expands any environment variables and user home directory references.
 //
 // This function performs the following expansions:
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -117,3 +118,17 @@ func (s *UtilSuite) TestExpand() {
 		})
 	}
 }
+
+func (s *UtilSuite) TestNewTempDir() {
+	dir, cleanup, err := NewTempDir("pathlib-test-")
+	s.Require().NoError(err)
+	s.DirExists(dir.String())
+	s.True(strings.HasPrefix(dir.Name, "pathlib-test-"))
+
+	s.Require().NoError(dir.Join("sub", "file.txt").WriteText("data"))
+
+	cleanup()
+	s.NoDirExists(dir.String())
+
+	s.NotPanics(cleanup)
+}
@@ -0,0 +1,109 @@
+package pathlib
+
+import (
+	"context"
+	"time"
+)
+
+// defaultWaitInterval is the poll interval used when a non-positive one is given.
+const defaultWaitInterval = 100 * time.Millisecond
+
+// WaitForExists polls the path every interval until it exists or ctx is done, e.g. to wait
+// for another process to produce a file.
+//
+// The path is checked immediately, so WaitForExists returns without delay if it already exists.
+//
+// Parameters:
+//   - ctx: Cancelling ctx or reaching its deadline stops waiting.
+//   - interval: The time between two checks. Zero or a negative value means 100ms.
+//
+// Returns:
+//   - error: nil once the path exists, or ctx.Err() if ctx is done first.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//
+//	if err := Path("/tmp/job/done.flag").WaitForExists(ctx, 100*time.Millisecond); err != nil {
+//	    log.Fatal("job did not finish in time")
+//	}
+func (p *FsPath) WaitForExists(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(waitInterval(interval))
+	defer ticker.Stop()
+
+	for {
+		p.Invalidate()
+
+		if exists, err := p.ExistsErr(); err == nil && exists {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForStable waits like WaitForExists until the path exists, then keeps polling every
+// interval until its size and modification time have not changed for at least quiet,
+// e.g. to wait for a download that is still being written.
+//
+// Parameters:
+//   - ctx: Cancelling ctx or reaching its deadline stops waiting.
+//   - interval: The time between two checks. Zero or a negative value means 100ms.
+//   - quiet: How long the file must stay unchanged to be considered complete.
+//
+// Returns:
+//   - error: nil once the file is stable, or ctx.Err() if ctx is done first.
+//
+// Note: A writer that pauses for longer than quiet is indistinguishable from a finished one,
+// so choose quiet according to the expected pauses of the writer.
+func (p *FsPath) WaitForStable(ctx context.Context, interval, quiet time.Duration) error {
+	if err := p.WaitForExists(ctx, interval); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(waitInterval(interval))
+	defer ticker.Stop()
+
+	var (
+		lastSize    int64 = -1
+		lastModTime time.Time
+		stableSince time.Time
+	)
+
+	for {
+		p.Invalidate()
+
+		if info, err := p.Stat(); err == nil {
+			now := time.Now()
+
+			if info.Size() != lastSize || !info.ModTime().Equal(lastModTime) {
+				lastSize, lastModTime, stableSince = info.Size(), info.ModTime(), now
+			} else if now.Sub(stableSince) >= quiet {
+				return nil
+			}
+		} else {
+			lastSize, stableSince = -1, time.Time{}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitInterval returns interval, or defaultWaitInterval if it is not positive,
+// since time.NewTicker panics on those.
+func waitInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultWaitInterval
+	}
+
+	return interval
+}
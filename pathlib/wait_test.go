@@ -0,0 +1,59 @@
+package pathlib
+
+import (
+	"context"
+	"time"
+)
+
+func (s *PathSuite) TestWaitForExists() {
+	file := Path(s.tempDir).Join("produced.txt")
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = file.Clone().WriteText(_testContent)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	s.Require().NoError(file.WaitForExists(ctx, 5*time.Millisecond))
+	s.Less(time.Since(start), time.Second)
+	s.FileExists(file.String())
+
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+
+	err := Path(s.tempDir).Join("never.txt").WaitForExists(short, 5*time.Millisecond)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *PathSuite) TestWaitForStable() {
+	file := Path(s.tempDir).Join("download.part")
+
+	go func() {
+		writer := file.Clone()
+		for i := 0; i < 5; i++ {
+			_ = writer.AppendText("chunk")
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	s.Require().NoError(file.WaitForStable(ctx, 5*time.Millisecond, 60*time.Millisecond))
+	s.Equal("chunkchunkchunkchunkchunk", file.MustReadText())
+}
+
+func (s *PathSuite) TestWaitNonPositiveInterval() {
+	file := Path(s.createTempFile("ready.txt", _testContent))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	s.NotPanics(func() {
+		s.NoError(file.WaitForExists(ctx, 0))
+		s.NoError(file.WaitForStable(ctx, -time.Second, 50*time.Millisecond))
+	})
+}
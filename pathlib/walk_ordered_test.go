@@ -0,0 +1,83 @@
+package pathlib
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func (s *PathSuite) TestWalkOrdered() {
+	root := Path(s.tempDir).Join("logs")
+	base := time.Now().Add(-time.Hour)
+
+	files := []struct {
+		name string
+		size int
+		age  time.Duration
+	}{
+		{"a.log", 30, 10 * time.Minute},
+		{"b.log", 10, 30 * time.Minute},
+		{"c.log", 20, 20 * time.Minute},
+	}
+
+	for _, f := range files {
+		file := root.Join(f.name)
+		s.Require().NoError(file.WriteBytes(make([]byte, f.size)))
+
+		mtime := base.Add(-f.age)
+		s.Require().NoError(os.Chtimes(file.String(), mtime, mtime))
+	}
+
+	collect := func(order WalkOrder) []string {
+		var visited []string
+
+		err := root.WalkOrdered(order, func(path string, info fs.FileInfo, err error) error {
+			s.Require().NoError(err)
+
+			if !info.IsDir() {
+				visited = append(visited, path)
+			}
+
+			return nil
+		})
+		s.Require().NoError(err)
+
+		return visited
+	}
+
+	s.Equal([]string{"a.log", "b.log", "c.log"}, collect(ByName))
+	s.Equal([]string{"b.log", "c.log", "a.log"}, collect(ByModTimeAsc))
+	s.Equal([]string{"a.log", "c.log", "b.log"}, collect(ByModTimeDesc))
+	s.Equal([]string{"a.log", "c.log", "b.log"}, collect(BySizeDesc))
+}
+
+func (s *PathSuite) TestWalkOrderedSkipDir() {
+	root := Path(s.tempDir).Join("tree")
+	s.Require().NoError(root.Join("skip", "hidden.txt").WriteText("x"))
+	s.Require().NoError(root.Join("visit", "seen.txt").WriteText("x"))
+	s.Require().NoError(root.Join("z.txt").WriteText("x"))
+
+	var visited []string
+
+	err := root.WalkOrdered(ByName, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		visited = append(visited, path)
+
+		if path == "skip" {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{".", "skip", "visit", filepath.Join("visit", "seen.txt"), "z.txt"}, visited)
+
+	err = root.Join("missing").WalkOrdered(ByName, func(_ string, _ fs.FileInfo, err error) error {
+		return err
+	})
+	s.ErrorIs(err, os.ErrNotExist)
+}
@@ -8,6 +8,18 @@ import (
 	"go.uber.org/zap"
 )
 
+// BackoffStrategy defines how the delay grows between successive attempts of a Sleeper.
+type BackoffStrategy int
+
+const (
+	// BackoffExponential doubles the delay on every attempt: base * 2^(attempt-1). This is the default.
+	BackoffExponential BackoffStrategy = iota
+	// BackoffLinear grows the delay linearly: base * attempt.
+	BackoffLinear
+	// BackoffConstant always uses the base delay.
+	BackoffConstant
+)
+
 // Sleeper implements exponential backoff with optional jitter for retry mechanisms.
 // It provides configurable base and maximum delay durations, as well as the ability
 // to enable/disable random jitter to prevent thundering herd problems in distributed systems.
@@ -17,6 +29,13 @@ type Sleeper struct {
 	baseDelay time.Duration
 	maxDelay  time.Duration
 	useJitter bool
+	strategy  BackoffStrategy
+	onSleep   func(attempt int, delay time.Duration)
+
+	maxElapsed time.Duration
+	elapsed    time.Duration
+	// budgetRefused is set when a minimum delay didn't fit in the remaining budget.
+	budgetRefused bool
 }
 
 // NewSleeper creates a new Sleeper for implementing exponential backoff delays.
@@ -29,6 +48,7 @@ type Sleeper struct {
 // The returned Sleeper can be further configured using:
 //   - WithDelays() to customize the base and max delay durations
 //   - WithJitter() to enable/disable random jitter
+//   - WithBackoff() to switch between exponential, linear and constant backoff
 //
 // Example usage:
 //
@@ -67,39 +87,223 @@ func (s *Sleeper) WithJitter(enable bool) *Sleeper {
 	return s
 }
 
+// SleepInfo describes a single backoff sleep performed by a Sleeper.
+type SleepInfo struct {
+	// Attempt is the 1-based number of this sleep since the last Reset.
+	Attempt int
+	// BaseDelay is the computed backoff delay before jitter is applied.
+	BaseDelay time.Duration
+	// Jitter is the random extra delay added to BaseDelay, zero when jitter is disabled.
+	Jitter time.Duration
+	// TotalDelay is the actual sleep duration, BaseDelay plus Jitter.
+	TotalDelay time.Duration
+}
+
+// WithBackoff sets the strategy used to grow the delay between attempts.
+// Whatever the strategy, the delay is capped at the max delay and jitter is applied if enabled.
+func (s *Sleeper) WithBackoff(strategy BackoffStrategy) *Sleeper {
+	s.strategy = strategy
+	return s
+}
+
+// WithOnSleep registers a callback invoked on every sleep, just before sleeping,
+// with the 1-based attempt number and the final (jittered) delay.
+// It is useful for metrics without depending on zap. Passing nil removes the callback.
+func (s *Sleeper) WithOnSleep(fn func(attempt int, delay time.Duration)) *Sleeper {
+	s.onSleep = fn
+	return s
+}
+
+// WithMaxElapsed bounds the total time the Sleeper may spend sleeping, summed over the
+// actual (jittered) sleep durations since the last Reset. Once the budget is used up,
+// Exhausted reports true and Retry stops, and a sleep that would exceed the budget is
+// shortened to the remaining time. A zero duration disables the budget (the default).
+func (s *Sleeper) WithMaxElapsed(d time.Duration) *Sleeper {
+	s.maxElapsed = d
+	return s
+}
+
+// Elapsed returns the total time slept since the last Reset.
+func (s *Sleeper) Elapsed() time.Duration {
+	return s.elapsed
+}
+
+// Exhausted reports whether the budget set with WithMaxElapsed has been used up, or a
+// SleepAtLeast minimum didn't fit in what was left of it.
+// It always returns false when no budget is set.
+func (s *Sleeper) Exhausted() bool {
+	return s.maxElapsed > 0 && (s.elapsed >= s.maxElapsed || s.budgetRefused)
+}
+
+// Retry calls fn until it succeeds, sleeping with backoff between failed attempts.
+//
+// It stops after maxAttempts calls, or earlier once the WithMaxElapsed budget is exhausted,
+// whichever comes first. A maxAttempts of 0 or less means no attempt cap, in which case a
+// budget should be set to avoid retrying forever.
+//
+// Returns nil as soon as fn succeeds, otherwise the error of the last attempt.
+//
+// Example usage:
+//
+//	sleeper := NewSleeper(logger).WithDelays(time.Second, 30*time.Second).WithMaxElapsed(2 * time.Minute)
+//	err := sleeper.Retry(0, func() error {
+//	    return client.Ping()
+//	})
+func (s *Sleeper) Retry(maxAttempts int, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if (maxAttempts > 0 && attempt >= maxAttempts) || s.Exhausted() {
+			return err
+		}
+
+		s.Sleep()
+	}
+}
+
 // Sleep performs exponential backoff sleep with optional jitter and logging.
 // When jitter is enabled, the actual sleep time will be between the calculated
 // delay and up to 2x that value.
 // Returns actual sleep duration for information purposes.
 func (s *Sleeper) Sleep() time.Duration {
-	// Calculate base exponential delay
-	baseDelay := time.Duration(math.Min(
-		float64(s.baseDelay)*math.Pow(2, float64(s.attempts)),
-		float64(s.maxDelay),
-	))
+	return s.SleepVerbose().TotalDelay
+}
+
+// SleepVerbose performs the same backoff sleep as Sleep, but returns the details
+// of the computed delay, e.g. for exporting metrics.
+func (s *Sleeper) SleepVerbose() SleepInfo {
+	return s.sleep(0)
+}
+
+// SleepAtLeast performs a backoff sleep like Sleep, but sleeps for at least minDelay,
+// e.g. a parsed Retry-After header. The computed backoff is still capped at maxDelay,
+// but minDelay is not, so a server-provided value can exceed it. The attempt counter
+// is incremented as with Sleep.
+//
+// minDelay is never shortened to fit the WithMaxElapsed budget: if it exceeds the remaining
+// budget, SleepAtLeast returns 0 immediately without sleeping or counting an attempt, and
+// Exhausted reports true from then on, until Reset.
+// Returns actual sleep duration for information purposes.
+func (s *Sleeper) SleepAtLeast(minDelay time.Duration) time.Duration {
+	return s.sleep(minDelay).TotalDelay
+}
+
+// sleep performs one backoff sleep of at least minDelay and advances the attempt counter.
+func (s *Sleeper) sleep(minDelay time.Duration) SleepInfo {
+	baseDelay := s.backoffDelay()
+
+	info := SleepInfo{
+		Attempt:    s.attempts + 1,
+		BaseDelay:  baseDelay,
+		TotalDelay: baseDelay,
+	}
+
+	if remaining := s.maxElapsed - s.elapsed; s.maxElapsed > 0 && minDelay > remaining {
+		// the caller's minimum is mandatory, so give up instead of retrying too early
+		s.budgetRefused = true
+
+		s.logger.Info("minimum delay exceeds the remaining time budget",
+			zap.Duration("min_delay", minDelay),
+			zap.Duration("remaining", max(remaining, 0)),
+			zap.Int("attempt", info.Attempt))
+
+		return SleepInfo{Attempt: info.Attempt, BaseDelay: baseDelay}
+	}
 
-	actualDelay := baseDelay
 	if s.useJitter {
 		// Add random jitter between 0% to 100% of calculated delay
-		jitter := time.Duration(rand.Float64() * float64(baseDelay))
-		actualDelay = baseDelay + jitter
+		info.Jitter = time.Duration(rand.Float64() * float64(baseDelay))
+		info.TotalDelay = baseDelay + info.Jitter
 
 		s.logger.Info("backing off with jitter",
 			zap.Duration("base_delay", baseDelay),
-			zap.Duration("jittered_delay", actualDelay),
-			zap.Int("attempt", s.attempts+1))
+			zap.Duration("jittered_delay", info.TotalDelay),
+			zap.Int("attempt", info.Attempt))
 	} else {
 		s.logger.Info("backing off",
-			zap.Duration("delay", actualDelay),
-			zap.Int("attempt", s.attempts+1))
+			zap.Duration("delay", info.TotalDelay),
+			zap.Int("attempt", info.Attempt))
+	}
+
+	if info.TotalDelay < minDelay {
+		info.TotalDelay = minDelay
+
+		s.logger.Info("backing off for the requested minimum delay",
+			zap.Duration("min_delay", minDelay),
+			zap.Int("attempt", info.Attempt))
+	}
+
+	if remaining := s.maxElapsed - s.elapsed; s.maxElapsed > 0 && info.TotalDelay > remaining {
+		info.TotalDelay = max(remaining, 0)
+
+		s.logger.Info("backing off for the remaining time budget",
+			zap.Duration("max_elapsed", s.maxElapsed),
+			zap.Duration("delay", info.TotalDelay),
+			zap.Int("attempt", info.Attempt))
 	}
 
-	time.Sleep(actualDelay)
+	if s.onSleep != nil {
+		s.onSleep(info.Attempt, info.TotalDelay)
+	}
+
+	time.Sleep(info.TotalDelay)
 	s.attempts++
-	return actualDelay
+	s.elapsed += info.TotalDelay
+	return info
+}
+
+// NextDelay returns the delay the next Sleep would use, without sleeping or advancing the
+// attempt counter, e.g. to display a "retrying in 8s" countdown.
+//
+// It is the pre-jitter backoff delay for the current attempt, capped at maxDelay and at the
+// remaining WithMaxElapsed budget. With jitter enabled the actual sleep is randomly longer,
+// up to twice the returned delay (still within the budget).
+func (s *Sleeper) NextDelay() time.Duration {
+	delay := s.backoffDelay()
+
+	if remaining := s.maxElapsed - s.elapsed; s.maxElapsed > 0 && delay > remaining {
+		delay = max(remaining, 0)
+	}
+
+	return delay
 }
 
-// Reset resets the attempt counter to 0
+// backoffDelay calculates the pre-jitter delay for the current attempt, capped at maxDelay.
+func (s *Sleeper) backoffDelay() time.Duration {
+	var delay float64
+
+	switch s.strategy {
+	case BackoffLinear:
+		delay = float64(s.baseDelay) * float64(s.attempts+1)
+	case BackoffConstant:
+		delay = float64(s.baseDelay)
+	default:
+		delay = float64(s.baseDelay) * math.Pow(2, float64(s.attempts))
+	}
+
+	return time.Duration(math.Min(delay, float64(s.maxDelay)))
+}
+
+// Reset resets the attempt counter and the elapsed time to 0
 func (s *Sleeper) Reset() {
 	s.attempts = 0
+	s.elapsed = 0
+	s.budgetRefused = false
+}
+
+// Clone returns a copy of the Sleeper with the same configuration (logger, delays,
+// jitter, backoff strategy, callback and time budget) but with the attempt counter and
+// elapsed time reset to 0.
+// It lets a configured Sleeper serve as a template for independent retry loops,
+// e.g. one clone per goroutine.
+func (s *Sleeper) Clone() *Sleeper {
+	clone := *s
+	clone.attempts = 0
+	clone.elapsed = 0
+	clone.budgetRefused = false
+
+	return &clone
 }
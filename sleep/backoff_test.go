@@ -0,0 +1,203 @@
+package sleep
+
+import (
+	"errors"
+	"time"
+)
+
+var errTest = errors.New("test error")
+
+func (s *SleepSuite) TestSleepVerbose() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, 3*time.Millisecond).WithJitter(false)
+
+	expected := []SleepInfo{
+		{Attempt: 1, BaseDelay: time.Millisecond, TotalDelay: time.Millisecond},
+		{Attempt: 2, BaseDelay: 2 * time.Millisecond, TotalDelay: 2 * time.Millisecond},
+		{Attempt: 3, BaseDelay: 3 * time.Millisecond, TotalDelay: 3 * time.Millisecond},
+	}
+
+	for _, want := range expected {
+		s.Equal(want, sleeper.SleepVerbose())
+	}
+
+	sleeper.Reset()
+	s.Equal(time.Millisecond, sleeper.Sleep())
+}
+
+func (s *SleepSuite) TestSleepVerboseWithJitter() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, time.Second)
+
+	info := sleeper.SleepVerbose()
+	s.Equal(1, info.Attempt)
+	s.Equal(time.Millisecond, info.BaseDelay)
+	s.Equal(info.BaseDelay+info.Jitter, info.TotalDelay)
+	s.Less(info.Jitter, info.BaseDelay)
+}
+
+func (s *SleepSuite) TestWithOnSleep() {
+	var (
+		attempts []int
+		delays   []time.Duration
+	)
+
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, time.Second).WithJitter(false).
+		WithOnSleep(func(attempt int, delay time.Duration) {
+			attempts = append(attempts, attempt)
+			delays = append(delays, delay)
+		})
+
+	for i := 0; i < 3; i++ {
+		sleeper.Sleep()
+	}
+
+	s.Equal([]int{1, 2, 3}, attempts)
+	s.Equal([]time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}, delays)
+
+	// a nil callback is safe
+	s.NotPanics(func() {
+		sleeper.WithOnSleep(nil).Sleep()
+	})
+}
+
+func (s *SleepSuite) TestWithBackoff() {
+	ms := time.Millisecond
+
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		expected []time.Duration
+	}{
+		{"exponential", BackoffExponential, []time.Duration{1 * ms, 2 * ms, 4 * ms, 5 * ms}},
+		{"linear", BackoffLinear, []time.Duration{1 * ms, 2 * ms, 3 * ms, 4 * ms, 5 * ms, 5 * ms}},
+		{"constant", BackoffConstant, []time.Duration{1 * ms, 1 * ms, 1 * ms}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			sleeper := NewSleeper(nil).WithDelays(ms, 5*ms).WithJitter(false).WithBackoff(tt.strategy)
+
+			var got []time.Duration
+			for range tt.expected {
+				got = append(got, sleeper.Sleep())
+			}
+
+			s.Equal(tt.expected, got)
+		})
+	}
+}
+
+func (s *SleepSuite) TestNextDelay() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, 5*time.Millisecond).WithJitter(false)
+
+	for i := 0; i < 5; i++ {
+		next := sleeper.NextDelay()
+		s.Equal(next, sleeper.NextDelay(), "NextDelay doesn't advance the attempts")
+		s.Equal(next, sleeper.Sleep())
+	}
+
+	budget := NewSleeper(nil).WithDelays(10*time.Millisecond, time.Second).
+		WithJitter(false).WithMaxElapsed(15 * time.Millisecond)
+	s.Equal(10*time.Millisecond, budget.NextDelay())
+	budget.Sleep()
+	s.Equal(5*time.Millisecond, budget.NextDelay())
+	s.Equal(5*time.Millisecond, budget.Sleep())
+	s.Equal(time.Duration(0), budget.NextDelay())
+
+	jittered := NewSleeper(nil).WithDelays(time.Millisecond, time.Second)
+	info := jittered.SleepVerbose()
+	s.Equal(2*time.Millisecond, jittered.NextDelay())
+	s.Equal(time.Millisecond, info.BaseDelay)
+}
+
+func (s *SleepSuite) TestClone() {
+	template := NewSleeper(nil).WithDelays(time.Millisecond, 10*time.Millisecond).WithJitter(false)
+	template.Sleep()
+
+	first := template.Clone()
+	second := template.Clone()
+
+	s.Equal(1, first.SleepVerbose().Attempt)
+	s.Equal(2, first.SleepVerbose().Attempt)
+	s.Equal(1, second.SleepVerbose().Attempt)
+
+	// the template keeps its own counter
+	s.Equal(2, template.SleepVerbose().Attempt)
+}
+
+func (s *SleepSuite) TestSleepAtLeast() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, 2*time.Millisecond).WithJitter(false)
+
+	start := time.Now()
+	s.Equal(50*time.Millisecond, sleeper.SleepAtLeast(50*time.Millisecond))
+	s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+	// the computed backoff wins when it is larger
+	s.Equal(2*time.Millisecond, sleeper.SleepAtLeast(time.Microsecond))
+
+	// attempts keep counting
+	s.Equal(3, sleeper.SleepVerbose().Attempt)
+}
+
+func (s *SleepSuite) TestRetryWithMaxElapsed() {
+	sleeper := NewSleeper(nil).WithDelays(10*time.Millisecond, time.Second).
+		WithJitter(false).WithBackoff(BackoffConstant).WithMaxElapsed(35 * time.Millisecond)
+
+	calls := 0
+	err := sleeper.Retry(100, func() error {
+		calls++
+		return errTest
+	})
+
+	s.ErrorIs(err, errTest)
+	// 10ms + 10ms + 10ms + 5ms (shortened to the remaining budget) between 5 calls
+	s.Equal(5, calls)
+	s.Equal(35*time.Millisecond, sleeper.Elapsed())
+	s.True(sleeper.Exhausted())
+
+	sleeper.Reset()
+	s.False(sleeper.Exhausted())
+}
+
+func (s *SleepSuite) TestSleepAtLeastWithMaxElapsed() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, time.Second).
+		WithJitter(false).WithMaxElapsed(30 * time.Millisecond)
+
+	s.Equal(20*time.Millisecond, sleeper.SleepAtLeast(20*time.Millisecond))
+	s.False(sleeper.Exhausted())
+
+	// 10ms are left: a 20ms minimum is not shortened, the sleeper gives up instead
+	start := time.Now()
+	s.Equal(time.Duration(0), sleeper.SleepAtLeast(20*time.Millisecond))
+	s.Less(time.Since(start), 10*time.Millisecond)
+	s.True(sleeper.Exhausted())
+	s.Equal(20*time.Millisecond, sleeper.Elapsed())
+	s.Equal(1, sleeper.attempts, "the refused sleep is not counted")
+
+	sleeper.Reset()
+	s.False(sleeper.Exhausted())
+}
+
+func (s *SleepSuite) TestRetry() {
+	sleeper := NewSleeper(nil).WithDelays(time.Millisecond, time.Millisecond).WithJitter(false)
+
+	calls := 0
+	err := sleeper.Retry(5, func() error {
+		calls++
+		if calls < 3 {
+			return errTest
+		}
+
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(3, calls)
+
+	calls = 0
+	err = sleeper.Clone().Retry(2, func() error {
+		calls++
+		return errTest
+	})
+	s.ErrorIs(err, errTest)
+	s.Equal(2, calls)
+	s.False(sleeper.Exhausted(), "no budget set")
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 // CeilInt returns the ceiling of a/b as an integer.
@@ -33,6 +35,9 @@ func RandFloatX1k(minNum, maxNum float64) int {
 
 // RandRange sleeps for a random duration between minNum and maxNum seconds.
 //
+// msg is accepted for backward compatibility only and is not logged;
+// use RandRangeLog to record the sleep.
+//
 // @return actual sleep duration in milliseconds
 func RandRange(minNum, maxNum float64, msg ...string) int {
 	slept := RandFloatX1k(minNum, maxNum)
@@ -41,6 +46,27 @@ func RandRange(minNum, maxNum float64, msg ...string) int {
 	return slept
 }
 
+// RandRangeLog sleeps like RandRange and logs msg at info level with the
+// requested range and the actual sleep duration. A nil logger disables logging.
+//
+// @return actual sleep duration in milliseconds
+func RandRangeLog(logger *zap.Logger, minNum, maxNum float64, msg string) int {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	slept := RandFloatX1k(minNum, maxNum)
+
+	logger.Info(msg,
+		zap.Float64("min_seconds", minNum),
+		zap.Float64("max_seconds", maxNum),
+		zap.Duration("slept", time.Duration(slept)*time.Millisecond))
+
+	time.Sleep(time.Duration(slept) * time.Millisecond)
+
+	return slept
+}
+
 func randNS(num float64, scales ...float64) int {
 	scale := 2.0
 	if len(scales) > 0 {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type SleepSuite struct {
@@ -30,6 +32,24 @@ func (s *SleepSuite) TestRandFloatX1k() {
 	}
 }
 
+func (s *SleepSuite) TestRandRangeLog() {
+	core, logs := observer.New(zap.InfoLevel)
+
+	slept := RandRangeLog(zap.New(core), 0.01, 0.02, "waiting for rate limit")
+	s.GreaterOrEqual(slept, 10)
+	s.LessOrEqual(slept, 20)
+
+	entries := logs.FilterMessage("waiting for rate limit").All()
+	s.Require().Len(entries, 1)
+
+	fields := entries[0].ContextMap()
+	s.Equal(time.Duration(slept)*time.Millisecond, fields["slept"])
+	s.InDelta(0.01, fields["min_seconds"], 1e-9)
+	s.InDelta(0.02, fields["max_seconds"], 1e-9)
+
+	s.NotPanics(func() { RandRangeLog(nil, 0.001, 0.002, "nop") })
+}
+
 func (s *SleepSuite) TestRandRange() {
 	start := time.Now()
 	slept := RandRange(1.0, 2.0)